@@ -1,6 +1,6 @@
 module snyk/Application-Security/snyk-sdk
 
-go 1.20
+go 1.21
 
 require (
 	github.com/alecthomas/assert/v2 v2.6.0
@@ -2,47 +2,67 @@ package snyk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
-	"math"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	baseURL           = "https://api.snyk.io/"
+	defaultBaseURL    = "https://api.snyk.io/"
 	defaultAPIVersion = "2023-09-14~beta"
 	defaultMaxRetries = 6
+	defaultUserAgent  = "snyk-sdk-go"
 )
 
 // Client provides methods for working with the Snyk API
 type Client struct {
-	httpClient *http.Client
-	token      string
-	APIVersion string
-	common     service
-	Orgs       *OrgsService
-	Users      *UsersService
-	Groups     *GroupsService
-	maxRetries int
+	httpClient  *http.Client
+	baseURL     string
+	userAgent   string
+	token       string
+	APIVersion  string
+	common      service
+	Orgs        *OrgsService
+	Users       *UsersService
+	Groups      *GroupsService
+	maxRetries  int
+	retryPolicy RetryPolicy
+	rateLimiter *rateLimiter
+	clock       Clock
+	logger      *slog.Logger
+	observer    Observer
+	maxPages    int
 }
 
 type service struct {
 	client *Client
 }
 
-// NewClient creates a new Snyk API client.
-func NewClient(token string) *Client {
+// NewClient creates a new Snyk API client. By default, the client sends requests to the US region using
+// `http.DefaultClient`. This can be changed by passing any number of `ClientOption`s.
+func NewClient(token string, opts ...ClientOption) *Client {
 	c := &Client{
-		httpClient: http.DefaultClient,
-		token:      token,
-		APIVersion: defaultAPIVersion,
-		maxRetries: defaultMaxRetries,
+		httpClient:  http.DefaultClient,
+		baseURL:     defaultBaseURL,
+		userAgent:   defaultUserAgent,
+		token:       token,
+		APIVersion:  defaultAPIVersion,
+		maxRetries:  defaultMaxRetries,
+		retryPolicy: DefaultRetryPolicy(),
+		rateLimiter: newRateLimiter(),
+		clock:       systemClock{},
+		logger:      slog.New(discardHandler{}),
+		observer:    NoopObserver{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
 
 	c.common.client = c
@@ -55,18 +75,17 @@ func NewClient(token string) *Client {
 }
 
 // SetMaxRetries sets how many times a failed request will be retried before returning an error
-// (default = 6). Requests are retried when the client receives a 429 or 500 response.
-// Each retry delay is caculated as `delay = 2^i` where delay is the time to wait in seconds and
-// `i` is the current retry iteration. So a `maxRetry` value of 6 would wait a maximum of
-// `2^6 = 64 seconds` on its final iteration.
+// (default = 6). Which failures are retried, and how long to wait between retries, is decided by the client's
+// RetryPolicy. See DefaultRetryPolicy for the default behavior.
 func (c *Client) SetMaxRetries(retries int) {
 	c.maxRetries = retries
 }
 
 // General function for sending requests to Snyk. The urlPath parameter should not include the domain for the request,
-// only the path. The domain will be prepended.
-func (c *Client) send(method string, urlPath string, params url.Values, body any) (*http.Response, error) {
-	requestURL, err := getReqURL(urlPath)
+// only the path. The domain will be prepended. If `ctx` is cancelled while a request or a retry delay is in progress,
+// `ctx.Err()` is returned.
+func (c *Client) send(ctx context.Context, method string, urlPath string, params url.Values, body any) (*http.Response, error) {
+	requestURL, err := c.getReqURL(urlPath)
 	if err != nil {
 		return nil, err
 	}
@@ -75,21 +94,23 @@ func (c *Client) send(method string, urlPath string, params url.Values, body any
 		requestURL.RawQuery = params.Encode()
 	}
 
+	// The body is buffered so that `http.NewRequest` sets `GetBody`, allowing it to be replayed on retries
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("Failed to serialize request body; %s", err.Error())
+			return nil, fmt.Errorf("Failed to serialize request body; %w", err)
 		}
-		bodyReader = bytes.NewBuffer(jsonBody)
+		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, requestURL.String(), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), bodyReader)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", c.token)
 	req.Header.Set("accept", "*/*")
+	req.Header.Set("User-Agent", c.userAgent)
 
 	if strings.HasPrefix(strings.TrimPrefix(urlPath, "/"), "rest/") {
 		req.Header.Set("Content-Type", "application/vnd.api+json")
@@ -97,50 +118,88 @@ func (c *Client) send(method string, urlPath string, params url.Values, body any
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	retryResponseCodes := []int{429, 500}
-	if isInSlice(resp.StatusCode, retryResponseCodes) {
-		for i := 0; i <= defaultMaxRetries; i++ {
-			retryAfter := resp.Header.Get("Retry-After")
-			retryAfterInt, err := strconv.Atoi(retryAfter)
-
-			var interval time.Duration
-			if err == nil {
-				// The `/v1/groups/GROUP_ID/members` endpoint has a rate limit of 1 request per minute
-				// It also seems to accumulate that wait time if multiple requests are made within the timeout
-				// https://snyk.docs.apiary.io/#reference/groups/list-members-in-a-group
-				log.Printf("Got %d. Retrying in %d seconds.", resp.StatusCode, retryAfterInt+5)
-				interval = time.Duration(retryAfterInt + 5)
-			} else {
-				interval = time.Duration(math.Exp2(float64(i)))
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
 			}
-			time.Sleep(interval * time.Second)
+		}
 
-			resp, err = c.httpClient.Do(req)
-			if !isInSlice(resp.StatusCode, retryResponseCodes) {
-				break
-			}
+		release, waitErr := c.rateLimiter.wait(ctx, c.clock, urlPath)
+		if waitErr != nil {
+			return nil, waitErr
+		}
+
+		info := RequestInfo{
+			Method:       method,
+			Path:         requestURL.Path,
+			PathTemplate: PathTemplate(requestURL.Path),
+			Attempt:      attempt,
+		}
+		attemptCtx := c.observer.RequestStart(ctx, info)
+
+		start := c.clock.Now()
+		resp, err = c.httpClient.Do(req.WithContext(attemptCtx))
+		latency := c.clock.Now().Sub(start)
+		release()
+
+		result := RequestResult{RequestInfo: info, Duration: latency, Err: err}
+		if resp != nil {
+			c.rateLimiter.update(resp.Header, c.clock.Now())
+			result.StatusCode = resp.StatusCode
+			result.RequestID = resp.Header.Get("snyk-request-id")
+		}
+		c.observer.RequestFinish(attemptCtx, result)
+		if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		var delay time.Duration
+		retry := false
+		if attempt < c.maxRetries {
+			delay, retry = c.retryPolicy.Retry(RetryAttempt{
+				Attempt:  attempt,
+				Request:  req,
+				Response: resp,
+				Err:      err,
+				Now:      c.clock.Now(),
+			})
+		}
+
+		c.logAttempt(ctx, req, resp, err, attempt, latency, retry, delay)
+		if !retry {
+			break
+		}
+		c.observer.Retry(ctx, info, delay)
+
+		if resp != nil {
+			// Drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := c.clock.Sleep(ctx, delay); err != nil {
+			return nil, err
 		}
 	}
 
-	if resp.StatusCode == 502 {
-		time.Sleep(30 * time.Second)
-		resp, err = c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("error: %s", getRespBody(resp))
+		return nil, newAPIError(resp, c.token)
 	}
 
-	return resp, err
+	return resp, nil
 }
 
-func getReqURL(urlPath string) (*url.URL, error) {
-	requestPath, err := joinURLParts(baseURL, urlPath)
+// getReqURL joins the given path to the client's base URL. Absolute URLs, such as pagination links, only have their
+// path and query string used so that requests are always sent to the configured base URL.
+func (c *Client) getReqURL(urlPath string) (*url.URL, error) {
+	requestPath, err := joinURLParts(c.baseURL, urlPath)
 	if err != nil {
 		return nil, err
 	}
@@ -150,41 +209,57 @@ func getReqURL(urlPath string) (*url.URL, error) {
 
 // Get sends a get request to the Snyk API
 func (c *Client) Get(urlPath string, params url.Values) (*http.Response, error) {
-	resp, err := c.send(http.MethodGet, urlPath, params, nil)
+	return c.GetWithContext(context.Background(), urlPath, params)
+}
+
+// GetWithContext sends a get request to the Snyk API using the provided context
+func (c *Client) GetWithContext(ctx context.Context, urlPath string, params url.Values) (*http.Response, error) {
+	resp, err := c.send(ctx, http.MethodGet, urlPath, params, nil)
 	return resp, err
 }
 
 // Post sends a post request to the Snyk API
 func (c *Client) Post(urlPath string, params url.Values, body any) (*http.Response, error) {
-	resp, err := c.send(http.MethodPost, urlPath, params, body)
+	return c.PostWithContext(context.Background(), urlPath, params, body)
+}
+
+// PostWithContext sends a post request to the Snyk API using the provided context
+func (c *Client) PostWithContext(ctx context.Context, urlPath string, params url.Values, body any) (*http.Response, error) {
+	resp, err := c.send(ctx, http.MethodPost, urlPath, params, body)
 	return resp, err
 }
 
 // Put sends a put request to the Snyk API
 func (c *Client) Put(urlPath string, body any) (*http.Response, error) {
-	resp, err := c.send(http.MethodPut, urlPath, nil, body)
+	return c.PutWithContext(context.Background(), urlPath, body)
+}
+
+// PutWithContext sends a put request to the Snyk API using the provided context
+func (c *Client) PutWithContext(ctx context.Context, urlPath string, body any) (*http.Response, error) {
+	resp, err := c.send(ctx, http.MethodPut, urlPath, nil, body)
 	return resp, err
 }
 
 // Patch sends a patch request to the Snyk API
 func (c *Client) Patch(urlPath string, params url.Values, body any) (*http.Response, error) {
-	resp, err := c.send(http.MethodPatch, urlPath, params, body)
+	return c.PatchWithContext(context.Background(), urlPath, params, body)
+}
+
+// PatchWithContext sends a patch request to the Snyk API using the provided context
+func (c *Client) PatchWithContext(ctx context.Context, urlPath string, params url.Values, body any) (*http.Response, error) {
+	resp, err := c.send(ctx, http.MethodPatch, urlPath, params, body)
 	return resp, err
 }
 
 // Delete sends a delete request to the Snyk API
 func (c *Client) Delete(urlPath string, params url.Values) (*http.Response, error) {
-	resp, err := c.send(http.MethodDelete, urlPath, params, nil)
-	return resp, err
+	return c.DeleteWithContext(context.Background(), urlPath, params)
 }
 
-func getRespBody(resp *http.Response) string {
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "Could not get reponse body"
-	}
-	bodyString := string(bodyBytes)
-	return fmt.Sprintf("'%d': '%s'", resp.StatusCode, bodyString)
+// DeleteWithContext sends a delete request to the Snyk API using the provided context
+func (c *Client) DeleteWithContext(ctx context.Context, urlPath string, params url.Values) (*http.Response, error) {
+	resp, err := c.send(ctx, http.MethodDelete, urlPath, params, nil)
+	return resp, err
 }
 
 // Joins two parts of a URL into a full URL string, preserving the query string since Go doesn't include anything for doing this.
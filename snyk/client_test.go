@@ -0,0 +1,89 @@
+package snyk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func TestClientCancelledContext(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		Reply(200).
+		JSON(map[string]any{"data": []any{}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient("mock-token")
+	_, err := client.Orgs.GetAllWithContext(ctx)
+	assert.IsError(t, err, context.Canceled)
+}
+
+func TestClientContextCancelsRetryDelay(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		Persist().
+		Reply(429).
+		SetHeader("Retry-After", "60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient("mock-token")
+	start := time.Now()
+	_, err := client.GetWithContext(ctx, "/rest/orgs", nil)
+	assert.IsError(t, err, context.DeadlineExceeded)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestClientOptions(t *testing.T) {
+	defer gock.Off()
+
+	customBaseURL := "https://snyk.example.com/"
+
+	gock.New(customBaseURL).
+		Get("/rest/groups").
+		MatchParam("version", "2024-01-01").
+		MatchHeader("User-Agent", "my-agent").
+		Reply(200).
+		JSON(map[string]any{
+			"data":  []map[string]any{{"id": "group-1", "type": "group", "attributes": map[string]any{"name": "Group1"}}},
+			"links": map[string]any{"next": "https://api.snyk.io/rest/groups?starting_after=abc"},
+		})
+
+	gock.New(customBaseURL).
+		Get("/rest/groups").
+		MatchParam("version", "2024-01-01").
+		MatchParam("starting_after", "abc").
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{{"id": "group-2", "type": "group", "attributes": map[string]any{"name": "Group2"}}},
+		})
+
+	client := NewClient(
+		"mock-token",
+		WithRegion(RegionEU),
+		WithBaseURL(customBaseURL),
+		WithUserAgent("my-agent"),
+		WithAPIVersion("2024-01-01"),
+	)
+	groups, err := client.Groups.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(groups))
+	assert.True(t, gock.IsDone())
+}
+
+func TestClientWithTransportDoesNotModifyDefaultClient(t *testing.T) {
+	client := NewClient("mock-token", WithTransport(http.DefaultTransport))
+	assert.True(t, client.httpClient != http.DefaultClient)
+	assert.Equal(t, nil, http.DefaultClient.Transport)
+}
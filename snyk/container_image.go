@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -30,18 +31,21 @@ func (r *resource) intoContainerImage() ContainerImage {
 
 // GetAll gets all container images for the given org
 func (s *ContainerImagesService) GetAll() ([]ContainerImage, error) {
-	var images []ContainerImage
+	return s.GetAllWithContext(context.Background())
+}
+
+// GetAllWithContext gets all container images for the given org using the provided context
+func (s *ContainerImagesService) GetAllWithContext(ctx context.Context) ([]ContainerImage, error) {
+	return collectPages(ctx, s.Pager(PageOptions{}))
+}
+
+// Pager returns a Pager which fetches all container images for the given org one page at a time
+func (s *ContainerImagesService) Pager(opts PageOptions) *Pager[ContainerImage] {
 	path := fmt.Sprintf("/rest/orgs/%s/container_images", s.orgID)
 	params := url.Values{}
 	params.Set("version", "2024-01-23~beta")
-	resources, err := getMultiResource(s.client, path, params)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, r := range resources {
-		images = append(images, r.intoContainerImage())
-	}
 
-	return images, nil
+	return newPager(s.client, path, params, opts, func(r resource) ContainerImage {
+		return r.intoContainerImage()
+	})
 }
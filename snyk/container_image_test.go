@@ -20,19 +20,19 @@ func TestContainerImageGetAll(t *testing.T) {
 	respJSONp2, err := loadFixture("fixtures/container_image_get_all_page2.json")
 	assert.NoError(t, err)
 
-	gock.New(baseURL).
+	gock.New(defaultBaseURL).
 		Get(fmt.Sprintf("/rest/orgs/%s", testOrgID)).
 		MatchParam("version", defaultAPIVersion).
 		Reply(200).
 		JSON(respJSONorg)
 
-	gock.New(baseURL).
+	gock.New(defaultBaseURL).
 		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
 		MatchParam("version", "2024-01-23~beta").
 		Reply(200).
 		JSON(respJSONp1)
 
-	gock.New(baseURL).
+	gock.New(defaultBaseURL).
 		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
 		MatchParam("version", "2024-01-23~beta").
 		MatchParam("starting_after", "v2.mXj4PTbNK6N_N7K3lcYwjZvuYLBeeSCSNfK9EznyHAo=").
@@ -0,0 +1,298 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DepGraph is the dependency graph of a project, as reported by its latest test. Packages are listed once in `Pkgs`
+// and can appear in several nodes of the graph, one for each place they are depended on.
+type DepGraph struct {
+	SchemaVersion string             `json:"schemaVersion"`
+	PkgManager    DepGraphPkgManager `json:"pkgManager"`
+	Pkgs          []DepGraphPkg      `json:"pkgs"`
+	Graph         struct {
+		RootNodeID string         `json:"rootNodeId"`
+		Nodes      []DepGraphNode `json:"nodes"`
+	} `json:"graph"`
+}
+
+// DepGraphPkgManager is the package manager which a DepGraph was resolved with
+type DepGraphPkgManager struct {
+	Name         string `json:"name"`
+	Version      string `json:"version,omitempty"`
+	Repositories []struct {
+		Alias string `json:"alias"`
+	} `json:"repositories,omitempty"`
+}
+
+// DepGraphPkg is a package in a DepGraph
+type DepGraphPkg struct {
+	ID   string  `json:"id"`
+	Info PkgInfo `json:"info"`
+}
+
+// PkgInfo identifies a package by its name and version
+type PkgInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+func (p PkgInfo) String() string {
+	return p.Name + "@" + p.Version
+}
+
+// DepGraphNode is an occurrence of a package in a DepGraph, along with the nodes of its dependencies
+type DepGraphNode struct {
+	NodeID string `json:"nodeId"`
+	PkgID  string `json:"pkgId"`
+	Deps   []struct {
+		NodeID string `json:"nodeId"`
+	} `json:"deps"`
+}
+
+// DependencyType classifies how a package is depended on by a project
+type DependencyType string
+
+const (
+	// DependencyDirect is a package which the project depends on itself
+	DependencyDirect DependencyType = "direct"
+	// DependencyTransitive is a package which the project only depends on through other packages
+	DependencyTransitive DependencyType = "transitive"
+	// DependencyNone is a package which is not in the graph
+	DependencyNone DependencyType = ""
+)
+
+// GetDepGraph gets the dependency graph of the project
+func (p *Project) GetDepGraph() (DepGraph, error) {
+	return p.GetDepGraphWithContext(context.Background())
+}
+
+// GetDepGraphWithContext gets the dependency graph of the project using the provided context
+func (p *Project) GetDepGraphWithContext(ctx context.Context) (DepGraph, error) {
+	urlPath := fmt.Sprintf("/v1/org/%s/project/%s/dep-graph", p.orgID, p.ID)
+	respBody := struct {
+		DepGraph DepGraph `json:"depGraph"`
+	}{}
+
+	resp, err := p.client.GetWithContext(ctx, urlPath, nil)
+	if err != nil {
+		return DepGraph{}, fmt.Errorf("Failed to get project dep graph; %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return DepGraph{}, fmt.Errorf("Failed to get project dep graph; %w", err)
+	}
+
+	return respBody.DepGraph, nil
+}
+
+// depGraphIndex looks up the nodes and packages of a DepGraph by their IDs
+type depGraphIndex struct {
+	nodes map[string]DepGraphNode
+	pkgs  map[string]PkgInfo
+}
+
+func (g DepGraph) index() depGraphIndex {
+	index := depGraphIndex{
+		nodes: make(map[string]DepGraphNode, len(g.Graph.Nodes)),
+		pkgs:  make(map[string]PkgInfo, len(g.Pkgs)),
+	}
+	for _, node := range g.Graph.Nodes {
+		index.nodes[node.NodeID] = node
+	}
+	for _, pkg := range g.Pkgs {
+		index.pkgs[pkg.ID] = pkg.Info
+	}
+	return index
+}
+
+// Root returns the package of the project itself
+func (g DepGraph) Root() PkgInfo {
+	index := g.index()
+	return index.pkgs[index.nodes[g.Graph.RootNodeID].PkgID]
+}
+
+// DefaultMaxPaths is the number of paths returned by DepGraph.PathsTo when no limit is given
+const DefaultMaxPaths = 100
+
+// PathsTo returns the paths from the root of the graph to the package with the given name, up to `limit` of them
+// (DefaultMaxPaths if 0 or less). Graphs where packages are depended on from many places can have exponentially many
+// paths, so they are never all listed. If `versions` are given, only paths to those versions of the package are
+// returned. Each path starts with the root package and ends with the matching package.
+func (g DepGraph) PathsTo(name string, limit int, versions ...string) [][]PkgInfo {
+	if limit <= 0 {
+		limit = DefaultMaxPaths
+	}
+
+	index := g.index()
+	reachesPkg := g.nodesReaching(index, name, versions)
+	paths := [][]PkgInfo{}
+	onPath := map[string]bool{}
+
+	var walk func(nodeID string, path []PkgInfo)
+	walk = func(nodeID string, path []PkgInfo) {
+		// Nodes which don't lead to the package are skipped, so that every branch walked ends in a path. Dep graphs
+		// shouldn't contain cycles, but they are skipped in case one does.
+		if len(paths) >= limit || !reachesPkg[nodeID] || onPath[nodeID] {
+			return
+		}
+		onPath[nodeID] = true
+		defer delete(onPath, nodeID)
+
+		node := index.nodes[nodeID]
+		pkg := index.pkgs[node.PkgID]
+		path = append(path, pkg)
+
+		if nodeID != g.Graph.RootNodeID && pkgMatches(pkg, name, versions) {
+			paths = append(paths, append([]PkgInfo(nil), path...))
+			return
+		}
+
+		for _, dep := range node.Deps {
+			walk(dep.NodeID, path)
+		}
+	}
+	walk(g.Graph.RootNodeID, nil)
+
+	return paths
+}
+
+// nodesReaching returns the IDs of the nodes which are, or depend on, a node of the given package
+func (g DepGraph) nodesReaching(index depGraphIndex, name string, versions []string) map[string]bool {
+	dependents := map[string][]string{}
+	queue := []string{}
+	for _, node := range g.Graph.Nodes {
+		for _, dep := range node.Deps {
+			dependents[dep.NodeID] = append(dependents[dep.NodeID], node.NodeID)
+		}
+		if node.NodeID != g.Graph.RootNodeID && pkgMatches(index.pkgs[node.PkgID], name, versions) {
+			queue = append(queue, node.NodeID)
+		}
+	}
+
+	reaching := map[string]bool{}
+	for len(queue) > 0 {
+		nodeID := queue[0]
+		queue = queue[1:]
+		if reaching[nodeID] {
+			continue
+		}
+		reaching[nodeID] = true
+		queue = append(queue, dependents[nodeID]...)
+	}
+	return reaching
+}
+
+// PathsToIssue returns the paths from the root of the graph to the vulnerable package of the issue, up to `limit` of
+// them (DefaultMaxPaths if 0 or less)
+func (g DepGraph) PathsToIssue(issue Issue, limit int) [][]PkgInfo {
+	return g.PathsTo(issue.PkgName, limit, issue.PkgVersions...)
+}
+
+// DependencyType returns whether the package with the given name, and any of the given versions if there are some, is
+// a direct or transitive dependency of the project. Packages which are both are classified as direct.
+func (g DepGraph) DependencyType(name string, versions ...string) DependencyType {
+	for _, pkg := range g.DirectDependencies() {
+		if pkgMatches(pkg, name, versions) {
+			return DependencyDirect
+		}
+	}
+	for _, pkg := range g.TransitiveDependencies() {
+		if pkgMatches(pkg, name, versions) {
+			return DependencyTransitive
+		}
+	}
+	return DependencyNone
+}
+
+// DirectDependencies returns the packages which the root of the graph depends on
+func (g DepGraph) DirectDependencies() []PkgInfo {
+	index := g.index()
+	direct := []PkgInfo{}
+	seen := map[PkgInfo]bool{}
+	for _, dep := range index.nodes[g.Graph.RootNodeID].Deps {
+		pkg := index.pkgs[index.nodes[dep.NodeID].PkgID]
+		if !seen[pkg] {
+			seen[pkg] = true
+			direct = append(direct, pkg)
+		}
+	}
+	return direct
+}
+
+// TransitiveDependencies returns the packages which the root of the graph only depends on through other packages,
+// ordered by their distance from the root
+func (g DepGraph) TransitiveDependencies() []PkgInfo {
+	index := g.index()
+	seen := map[PkgInfo]bool{index.pkgs[index.nodes[g.Graph.RootNodeID].PkgID]: true}
+	for _, pkg := range g.DirectDependencies() {
+		seen[pkg] = true
+	}
+
+	transitive := []PkgInfo{}
+	visited := map[string]bool{}
+	queue := []string{g.Graph.RootNodeID}
+	for len(queue) > 0 {
+		node := index.nodes[queue[0]]
+		queue = queue[1:]
+		if visited[node.NodeID] {
+			continue
+		}
+		visited[node.NodeID] = true
+
+		pkg := index.pkgs[node.PkgID]
+		if !seen[pkg] {
+			seen[pkg] = true
+			transitive = append(transitive, pkg)
+		}
+		for _, dep := range node.Deps {
+			queue = append(queue, dep.NodeID)
+		}
+	}
+	return transitive
+}
+
+// ReverseIndex returns the packages which directly depend on each package in the graph
+func (g DepGraph) ReverseIndex() map[PkgInfo][]PkgInfo {
+	index := g.index()
+	reverse := map[PkgInfo][]PkgInfo{}
+	for _, node := range g.Graph.Nodes {
+		dependent := index.pkgs[node.PkgID]
+		for _, dep := range node.Deps {
+			pkg := index.pkgs[index.nodes[dep.NodeID].PkgID]
+			if !isInSlice(dependent, reverse[pkg]) {
+				reverse[pkg] = append(reverse[pkg], dependent)
+			}
+		}
+	}
+	return reverse
+}
+
+// Dependents returns the packages which directly depend on the package with the given name, and any of the given
+// versions if there are some. The packages are sorted by name and version.
+func (g DepGraph) Dependents(name string, versions ...string) []PkgInfo {
+	dependents := []PkgInfo{}
+	for pkg, pkgDependents := range g.ReverseIndex() {
+		if !pkgMatches(pkg, name, versions) {
+			continue
+		}
+		for _, dependent := range pkgDependents {
+			if !isInSlice(dependent, dependents) {
+				dependents = append(dependents, dependent)
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		return dependents[i].String() < dependents[j].String()
+	})
+	return dependents
+}
+
+func pkgMatches(pkg PkgInfo, name string, versions []string) bool {
+	return pkg.Name == name && (len(versions) == 0 || isInSlice(pkg.Version, versions))
+}
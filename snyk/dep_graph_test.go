@@ -0,0 +1,131 @@
+package snyk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func getTestDepGraph(t *testing.T) DepGraph {
+	t.Helper()
+
+	respJSON, err := loadFixture("fixtures/project_dep_graph.json")
+	assert.NoError(t, err)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/org/%s/project/%s/dep-graph", testOrgID, testProjectID)).
+		Reply(200).
+		JSON(respJSON)
+
+	client := NewClient("mock-token")
+	project := Project{ID: testProjectID, orgID: testOrgID, client: client}
+
+	graph, err := project.GetDepGraph()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	return graph
+}
+
+func TestDepGraphPaths(t *testing.T) {
+	defer gock.Off()
+
+	graph := getTestDepGraph(t)
+	assert.Equal(t, "npm", graph.PkgManager.Name)
+	assert.Equal(t, PkgInfo{Name: "goof", Version: "1.0.1"}, graph.Root())
+
+	goof := PkgInfo{Name: "goof", Version: "1.0.1"}
+	express := PkgInfo{Name: "express", Version: "4.12.4"}
+	bodyParser := PkgInfo{Name: "body-parser", Version: "1.9.0"}
+
+	assert.Equal(t, [][]PkgInfo{
+		{goof, express, {Name: "qs", Version: "2.4.2"}},
+		{goof, express, bodyParser, {Name: "qs", Version: "2.2.4"}},
+		{goof, bodyParser, {Name: "qs", Version: "2.2.4"}},
+	}, graph.PathsTo("qs", 0))
+	assert.Equal(t, 2, len(graph.PathsTo("qs", 2)))
+
+	issue := Issue{PkgName: "qs", PkgVersions: []string{"2.2.4"}}
+	assert.Equal(t, [][]PkgInfo{
+		{goof, express, bodyParser, {Name: "qs", Version: "2.2.4"}},
+		{goof, bodyParser, {Name: "qs", Version: "2.2.4"}},
+	}, graph.PathsToIssue(issue, 0))
+
+	assert.Equal(t, [][]PkgInfo{}, graph.PathsTo("left-pad", 0))
+}
+
+// wideDiamondGraph builds a graph of `layers` layers of two packages, where both packages of each layer depend on
+// both packages of the next, so there are 2^layers paths to `bottom`. `other` is a direct dependency of the root.
+func wideDiamondGraph(layers int) DepGraph {
+	graph := DepGraph{}
+	graph.Graph.RootNodeID = "root"
+	addNode := func(nodeID, name string, deps ...string) {
+		graph.Pkgs = append(graph.Pkgs, DepGraphPkg{ID: nodeID, Info: PkgInfo{Name: name, Version: "1.0.0"}})
+		node := DepGraphNode{NodeID: nodeID, PkgID: nodeID}
+		for _, dep := range deps {
+			node.Deps = append(node.Deps, struct {
+				NodeID string `json:"nodeId"`
+			}{NodeID: dep})
+		}
+		graph.Graph.Nodes = append(graph.Graph.Nodes, node)
+	}
+
+	next := []string{"bottom"}
+	addNode("bottom", "bottom")
+	for i := layers; i > 0; i-- {
+		layer := []string{fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i)}
+		for _, nodeID := range layer {
+			addNode(nodeID, nodeID, next...)
+		}
+		next = layer
+	}
+	addNode("other", "other")
+	addNode("root", "app", append(next, "other")...)
+
+	return graph
+}
+
+func TestDepGraphPathsToIsBounded(t *testing.T) {
+	graph := wideDiamondGraph(40)
+
+	paths := graph.PathsTo("bottom", 0)
+	assert.Equal(t, DefaultMaxPaths, len(paths))
+	assert.Equal(t, 42, len(paths[0]))
+	assert.Equal(t, PkgInfo{Name: "bottom", Version: "1.0.0"}, paths[0][41])
+
+	assert.Equal(t, 5, len(graph.PathsTo("bottom", 5)))
+
+	// Packages which the diamond doesn't lead to are found without walking it
+	assert.Equal(t, [][]PkgInfo{{
+		{Name: "app", Version: "1.0.0"},
+		{Name: "other", Version: "1.0.0"},
+	}}, graph.PathsTo("other", 0))
+}
+
+func TestDepGraphClassification(t *testing.T) {
+	defer gock.Off()
+
+	graph := getTestDepGraph(t)
+
+	assert.Equal(t, []PkgInfo{
+		{Name: "express", Version: "4.12.4"},
+		{Name: "body-parser", Version: "1.9.0"},
+		{Name: "lodash", Version: "4.17.4"},
+	}, graph.DirectDependencies())
+	assert.Equal(t, []PkgInfo{
+		{Name: "qs", Version: "2.4.2"},
+		{Name: "qs", Version: "2.2.4"},
+	}, graph.TransitiveDependencies())
+
+	assert.Equal(t, DependencyDirect, graph.DependencyType("body-parser"))
+	assert.Equal(t, DependencyTransitive, graph.DependencyType("qs", "2.4.2"))
+	assert.Equal(t, DependencyNone, graph.DependencyType("qs", "6.0.0"))
+
+	assert.Equal(t, []PkgInfo{
+		{Name: "body-parser", Version: "1.9.0"},
+		{Name: "express", Version: "4.12.4"},
+	}, graph.Dependents("qs"))
+	assert.Equal(t, []PkgInfo{{Name: "goof", Version: "1.0.1"}, {Name: "express", Version: "4.12.4"}},
+		graph.ReverseIndex()[PkgInfo{Name: "body-parser", Version: "1.9.0"}])
+}
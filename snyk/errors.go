@@ -0,0 +1,168 @@
+package snyk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors which an `*APIError` matches with `errors.Is` according to its status code
+var (
+	ErrNotFound     = errors.New("snyk: not found")
+	ErrUnauthorized = errors.New("snyk: unauthorized")
+	ErrForbidden    = errors.New("snyk: forbidden")
+	ErrRateLimited  = errors.New("snyk: rate limited")
+)
+
+// APIError is returned when the Snyk API responds with an error status code
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// The value of the `snyk-request-id` response header. Include this when contacting Snyk support.
+	RequestID string
+	// The errors described in the response body. v1 error bodies are converted into a single ErrorObject.
+	Errors []ErrorObject
+	// The raw response body
+	Body string
+}
+
+// ErrorObject is a single error returned by the Snyk API, following the JSON:API error object format
+type ErrorObject struct {
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	Source struct {
+		Pointer   string `json:"pointer,omitempty"`
+		Parameter string `json:"parameter,omitempty"`
+	} `json:"source,omitempty"`
+	Meta map[string]any `json:"meta,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("snyk: %s %s returned %d", e.Method, e.URL, e.StatusCode)
+
+	var details []string
+	for _, obj := range e.Errors {
+		switch {
+		case obj.Title != "" && obj.Detail != "":
+			details = append(details, fmt.Sprintf("%s: %s", obj.Title, obj.Detail))
+		case obj.Detail != "":
+			details = append(details, obj.Detail)
+		case obj.Title != "":
+			details = append(details, obj.Title)
+		}
+	}
+
+	if len(details) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(details, "; "))
+	} else if e.Body != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Body)
+	}
+
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request ID %s)", msg, e.RequestID)
+	}
+
+	return msg
+}
+
+// Is allows an `*APIError` to be matched against ErrNotFound, ErrUnauthorized, ErrForbidden and ErrRateLimited
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// PartialResultError is returned by methods which fetch every page of a list endpoint when a page fails after others
+// were fetched successfully. The items from the successful pages are returned alongside the error. The error which
+// stopped pagination can be inspected with `errors.Is` and `errors.As`.
+type PartialResultError struct {
+	Err error
+	// The number of pages fetched successfully
+	Pages int
+	// The cursor of the page which could not be fetched. Pass it as `PageOptions.StartingAfter` to resume fetching.
+	// It is empty if fetching can't be resumed because of ErrPaginationLoop.
+	Cursor string
+}
+
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("snyk: pagination stopped after %d pages: %s", e.Pages, e.Err.Error())
+}
+
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an `*APIError` from an error response, consuming and closing its body. Any occurrence of `token`
+// in the body is redacted.
+func newAPIError(resp *http.Response, token string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("snyk-request-id"),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	defer resp.Body.Close()
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return apiErr
+	}
+	apiErr.Body = redactToken(string(bodyBytes), token)
+	apiErr.Errors = parseErrorObjects([]byte(apiErr.Body))
+
+	return apiErr
+}
+
+// parseErrorObjects parses the `errors` array of a REST error body, or the message of a v1 error body
+func parseErrorObjects(body []byte) []ErrorObject {
+	restBody := struct {
+		Errors []ErrorObject `json:"errors"`
+	}{}
+	if err := json.Unmarshal(body, &restBody); err == nil && len(restBody.Errors) > 0 {
+		return restBody.Errors
+	}
+
+	// v1 errors look like `{"code": 404, "message": "...", "error": "..."}` although not every field is always set
+	v1Body := struct {
+		Code    any    `json:"code"`
+		Message string `json:"message"`
+		Error   any    `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &v1Body); err != nil {
+		return nil
+	}
+
+	obj := ErrorObject{Detail: v1Body.Message}
+	if v1Body.Code != nil {
+		obj.Code = fmt.Sprint(v1Body.Code)
+	}
+	if errMsg, ok := v1Body.Error.(string); ok {
+		if obj.Detail == "" {
+			obj.Detail = errMsg
+		} else {
+			obj.Title = errMsg
+		}
+	}
+
+	if obj.Detail == "" && obj.Title == "" {
+		return nil
+	}
+	return []ErrorObject{obj}
+}
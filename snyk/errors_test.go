@@ -0,0 +1,61 @@
+package snyk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func TestAPIErrorREST(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s", testOrgID)).
+		Reply(404).
+		SetHeader("snyk-request-id", "request-1").
+		JSON(map[string]any{
+			"jsonapi": map[string]any{"version": "1.0"},
+			"errors": []map[string]any{{
+				"status": "404",
+				"code":   "SNYK-0003",
+				"title":  "Not Found",
+				"detail": "The org was not found",
+			}},
+		})
+
+	client := NewClient("mock-token")
+	_, err := client.Orgs.Get(testOrgID)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrForbidden))
+	assert.Equal(t, 404, apiErr.StatusCode)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, "request-1", apiErr.RequestID)
+	assert.Equal(t, 1, len(apiErr.Errors))
+	assert.Equal(t, "SNYK-0003", apiErr.Errors[0].Code)
+	assert.Contains(t, err.Error(), "The org was not found")
+}
+
+func TestAPIErrorV1WrappedBySettings(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/org/%s/settings", testOrgID)).
+		Reply(401).
+		JSON(map[string]any{"code": 401, "message": "Invalid auth token provided", "error": "Unauthorized"})
+
+	client := NewClient("mock-token")
+	org := Org{ID: testOrgID, client: client}
+	_, err := org.GetSettings()
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+	assert.Equal(t, "401", apiErr.Errors[0].Code)
+	assert.Equal(t, "Invalid auth token provided", apiErr.Errors[0].Detail)
+}
@@ -1,9 +1,15 @@
 package snyk
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
+const groupTagsPageSize = 1000
+
 // Group represents a Snyk Group
 type Group struct {
 	ID     string
@@ -24,24 +30,30 @@ func (r *resource) intoGroup(client *Client) Group {
 
 // GetAll fetches all groups from Snyk
 func (s *GroupsService) GetAll() ([]Group, error) {
-	var groups []Group
-	path := "/rest/groups"
-	resources, err := getMultiResource(s.client, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return s.GetAllWithContext(context.Background())
+}
 
-	for _, r := range resources {
-		groups = append(groups, r.intoGroup(s.client))
-	}
+// GetAllWithContext fetches all groups from Snyk using the provided context
+func (s *GroupsService) GetAllWithContext(ctx context.Context) ([]Group, error) {
+	return collectPages(ctx, s.Pager(PageOptions{}))
+}
 
-	return groups, nil
+// Pager returns a Pager which fetches all groups one page at a time
+func (s *GroupsService) Pager(opts PageOptions) *Pager[Group] {
+	return newPager(s.client, "/rest/groups", nil, opts, func(r resource) Group {
+		return r.intoGroup(s.client)
+	})
 }
 
 // Get fetches a group from Snyk with the given `groupID`
 func (s *GroupsService) Get(groupID string) (Group, error) {
+	return s.GetWithContext(context.Background(), groupID)
+}
+
+// GetWithContext fetches a group from Snyk with the given `groupID` using the provided context
+func (s *GroupsService) GetWithContext(ctx context.Context, groupID string) (Group, error) {
 	path := fmt.Sprintf("/rest/groups/%s", groupID)
-	res, err := getSingleResource(s.client, path, nil)
+	res, err := getSingleResource(ctx, s.client, path, nil)
 	if err != nil {
 		return Group{}, err
 	}
@@ -51,15 +63,191 @@ func (s *GroupsService) Get(groupID string) (Group, error) {
 	return group, nil
 }
 
-// AddUserToOrg adds the given user to the given org within the group. `role` must be one of "admin" or "collaborator"
+// AddUserToOrg adds the given user to the given org within the group. `role` must be one of OrgRoleAdmin or
+// OrgRoleCollaborator, otherwise ErrInvalidRole is returned without sending a request. To give the user a custom role,
+// add them as a collaborator and then use UpdateUserRoleByName.
 func (g *Group) AddUserToOrg(org Org, user User, role string) error {
+	return g.AddUserToOrgWithContext(context.Background(), org, user, role)
+}
+
+// AddUserToOrgWithContext adds the given user to the given org within the group using the provided context
+func (g *Group) AddUserToOrgWithContext(ctx context.Context, org Org, user User, role string) error {
+	if err := validateOrgRole(role); err != nil {
+		return fmt.Errorf("Failed to add user to org; %w", err)
+	}
+
 	urlPath := fmt.Sprintf("/v1/group/%s/org/%s/members", g.ID, org.ID)
 	body := map[string]string{"userId": user.ID, "role": role}
 
-	_, err := g.client.Post(urlPath, nil, body)
+	_, err := g.client.PostWithContext(ctx, urlPath, nil, body)
+	if err != nil {
+		return fmt.Errorf("Failed to add user to org; %w", err)
+	}
+
+	return nil
+}
+
+// Orgs gets the orgs in the group. If `opts` are given, only the orgs matching the first of them are returned. Their
+// GroupID is ignored.
+func (g *Group) Orgs(opts ...ListOrgsOptions) ([]Org, error) {
+	return g.OrgsWithContext(context.Background(), opts...)
+}
+
+// OrgsWithContext gets the orgs in the group using the provided context. If `opts` are given, only the orgs matching
+// the first of them are returned.
+func (g *Group) OrgsWithContext(ctx context.Context, opts ...ListOrgsOptions) ([]Org, error) {
+	return collectPages(ctx, g.OrgsPager(PageOptions{}, opts...))
+}
+
+// OrgsPager returns a Pager which fetches the orgs in the group one page at a time. If `filters` are given, only the
+// orgs matching the first of them are returned.
+func (g *Group) OrgsPager(opts PageOptions, filters ...ListOrgsOptions) *Pager[Org] {
+	path := fmt.Sprintf("/rest/groups/%s/orgs", g.ID)
+	var params url.Values
+	if len(filters) > 0 {
+		params = filters[0].params()
+		params.Del("group_id")
+	}
+
+	return newPager(g.client, path, params, opts, func(r resource) Org {
+		org := r.intoOrg(g.client)
+		if org.GroupID == "" {
+			org.GroupID = g.ID
+		}
+		return org
+	})
+}
+
+// GroupSettings defines the configurable settings for a Snyk Group. Fields which are nil or 0 are left unchanged by
+// Group.UpdateSettings.
+type GroupSettings struct {
+	// The length of user sessions in minutes. If 0, Snyk's default is used.
+	SessionLength int `json:"sessionLength,omitempty"`
+	// Settings for requesting access to the group's orgs
+	RequestAccess *GroupRequestAccess `json:"requestAccess,omitempty"`
+}
+
+// GroupRequestAccess defines whether users can request access to the orgs in a group
+type GroupRequestAccess struct {
+	// Whether requesting access to the group's orgs is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// GetSettings returns the currently configured settings for the group
+func (g *Group) GetSettings() (GroupSettings, error) {
+	return g.GetSettingsWithContext(context.Background())
+}
+
+// GetSettingsWithContext returns the currently configured settings for the group using the provided context
+func (g *Group) GetSettingsWithContext(ctx context.Context) (GroupSettings, error) {
+	urlPath := fmt.Sprintf("/v1/group/%s/settings", g.ID)
+	settings := GroupSettings{}
+	resp, err := g.client.GetWithContext(ctx, urlPath, nil)
+	if err != nil {
+		return settings, fmt.Errorf("Failed to get group settings; %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&settings)
+	if err != nil {
+		return settings, fmt.Errorf("Failed to get group settings; %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings updates the provided settings on the group
+func (g *Group) UpdateSettings(settings GroupSettings) error {
+	return g.UpdateSettingsWithContext(context.Background(), settings)
+}
+
+// UpdateSettingsWithContext updates the provided settings on the group using the provided context
+func (g *Group) UpdateSettingsWithContext(ctx context.Context, settings GroupSettings) error {
+	urlPath := fmt.Sprintf("/v1/group/%s/settings", g.ID)
+
+	resp, err := g.client.PutWithContext(ctx, urlPath, settings)
+	if err != nil {
+		return fmt.Errorf("Failed to update group settings; %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// Tags gets every tag used on the projects in the group
+func (g *Group) Tags() ([]Tag, error) {
+	return g.TagsWithContext(context.Background())
+}
+
+// TagsWithContext gets every tag used on the projects in the group using the provided context
+func (g *Group) TagsWithContext(ctx context.Context) ([]Tag, error) {
+	urlPath := fmt.Sprintf("/v1/group/%s/tags", g.ID)
+
+	tags := []Tag{}
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("perPage", strconv.Itoa(groupTagsPageSize))
+		params.Set("page", strconv.Itoa(page))
+
+		pageTags, err := g.tagsPage(ctx, urlPath, params)
+		if err != nil {
+			if page > 1 {
+				return tags, &PartialResultError{Err: err, Pages: page - 1}
+			}
+			return nil, err
+		}
+
+		tags = append(tags, pageTags...)
+		if len(pageTags) < groupTagsPageSize {
+			return tags, nil
+		}
+	}
+}
+
+func (g *Group) tagsPage(ctx context.Context, urlPath string, params url.Values) ([]Tag, error) {
+	resp, err := g.client.GetWithContext(ctx, urlPath, params)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get group tags; %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody := struct {
+		Tags []Tag `json:"tags"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get group tags; %w", err)
+	}
+
+	return respBody.Tags, nil
+}
+
+// DeleteTagOptions sets how Group.DeleteTag deletes a tag
+type DeleteTagOptions struct {
+	// Delete the tag even if projects still use it, removing it from those projects
+	Force bool
+}
+
+// DeleteTag deletes the tag from the group. Unless `opts` force the deletion, Snyk refuses to delete tags which are
+// still used on projects.
+func (g *Group) DeleteTag(tag Tag, opts ...DeleteTagOptions) error {
+	return g.DeleteTagWithContext(context.Background(), tag, opts...)
+}
+
+// DeleteTagWithContext deletes the tag from the group using the provided context
+func (g *Group) DeleteTagWithContext(ctx context.Context, tag Tag, opts ...DeleteTagOptions) error {
+	urlPath := fmt.Sprintf("/v1/group/%s/tags/delete", g.ID)
+	body := map[string]any{
+		"key":   tag.Key,
+		"value": tag.Value,
+		"force": len(opts) > 0 && opts[0].Force,
+	}
+
+	resp, err := g.client.PostWithContext(ctx, urlPath, nil, body)
 	if err != nil {
-		return fmt.Errorf("Failed to add user to org; %s", err.Error())
+		return fmt.Errorf("Failed to delete group tag; %w", err)
 	}
+	resp.Body.Close()
 
 	return nil
 }
@@ -0,0 +1,277 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GroupMember is a user who is a member of a group, along with their role in the group and its orgs
+type GroupMember struct {
+	// The ID of the group membership, which is used to remove the member
+	MembershipID string
+	UserID       string
+	Name         string
+	Username     string
+	Email        string
+	// The name of the member's group role, e.g. `Group Admin`
+	GroupRole   string
+	GroupRoleID string
+	// The member's roles in the orgs of the group. Only set when requested with IncludeOrgRoles.
+	Orgs []GroupMemberOrg
+}
+
+// GroupMemberOrg is a group member's role in one of the group's orgs
+type GroupMemberOrg struct {
+	OrgID   string
+	OrgName string
+	Role    string
+	RoleID  string
+}
+
+// GroupMembershipsOptions sets what is returned by Group.Memberships
+type GroupMembershipsOptions struct {
+	// Also get each member's roles in the group's orgs. This lists the members of every org in the group one org at a
+	// time, so it makes at least one extra request per org: a group with 500 orgs takes 500 more requests, which are
+	// subject to the client's rate limits.
+	IncludeOrgRoles bool
+}
+
+func (r *resource) intoGroupMember() GroupMember {
+	member := GroupMember{MembershipID: r.ID}
+
+	if user, ok := r.Relationships["user"]; ok {
+		member.UserID = user.Data.ID
+		member.Name = stringAttribute(user.Data.Attributes, "name")
+		member.Username = stringAttribute(user.Data.Attributes, "username")
+		member.Email = stringAttribute(user.Data.Attributes, "email")
+	}
+	if role, ok := r.Relationships["role"]; ok {
+		member.GroupRoleID = role.Data.ID
+		member.GroupRole = stringAttribute(role.Data.Attributes, "name")
+	}
+
+	return member
+}
+
+// Memberships gets every member of the group along with their group role using the REST API, which isn't limited to
+// 1 request per minute like UsersService.GetAll. If `opts` are given, they set what is returned for each member.
+func (g *Group) Memberships(opts ...GroupMembershipsOptions) ([]GroupMember, error) {
+	return g.MembershipsWithContext(context.Background(), opts...)
+}
+
+// MembershipsWithContext gets every member of the group along with their group role using the provided context
+func (g *Group) MembershipsWithContext(ctx context.Context, opts ...GroupMembershipsOptions) ([]GroupMember, error) {
+	members, err := collectPages(ctx, g.MembershipsPager(PageOptions{}))
+	if err != nil {
+		return members, fmt.Errorf("Failed to get group memberships; %w", err)
+	}
+
+	if len(opts) > 0 && opts[0].IncludeOrgRoles {
+		if err := g.addOrgRoles(ctx, members); err != nil {
+			return members, fmt.Errorf("Failed to get group memberships; %w", err)
+		}
+	}
+
+	return members, nil
+}
+
+// MembershipsPager returns a Pager which fetches the members of the group one page at a time. Their org roles aren't
+// included.
+func (g *Group) MembershipsPager(opts PageOptions) *Pager[GroupMember] {
+	path := fmt.Sprintf("/rest/groups/%s/memberships", g.ID)
+	params := url.Values{}
+	params.Set("version", membershipsAPIVersion)
+
+	return newPager(g.client, path, params, opts, func(r resource) GroupMember {
+		return r.intoGroupMember()
+	})
+}
+
+// addOrgRoles sets the org roles of `members` from the members of each org in the group. Org.MembersWithContext is
+// used so that orgs without the REST memberships endpoint fall back to the v1 API.
+func (g *Group) addOrgRoles(ctx context.Context, members []GroupMember) error {
+	byUserID := make(map[string]*GroupMember, len(members))
+	for i := range members {
+		members[i].Orgs = []GroupMemberOrg{}
+		byUserID[members[i].UserID] = &members[i]
+	}
+
+	orgs, err := g.OrgsWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, org := range orgs {
+		orgMembers, err := org.MembersWithContext(ctx)
+		if err != nil {
+			return fmt.Errorf("org %s: %w", org.ID, err)
+		}
+
+		for _, orgMember := range orgMembers {
+			if member, ok := byUserID[orgMember.UserID]; ok {
+				member.Orgs = append(member.Orgs, GroupMemberOrg{
+					OrgID:   org.ID,
+					OrgName: org.Name,
+					Role:    orgMember.Role,
+					RoleID:  orgMember.RoleID,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveMember removes the member with the given `membershipID` from the group, and from every org in it
+func (g *Group) RemoveMember(membershipID string) error {
+	return g.RemoveMemberWithContext(context.Background(), membershipID)
+}
+
+// RemoveMemberWithContext removes the member with the given `membershipID` from the group using the provided context
+func (g *Group) RemoveMemberWithContext(ctx context.Context, membershipID string) error {
+	urlPath := fmt.Sprintf("/rest/groups/%s/memberships/%s", g.ID, membershipID)
+	params := url.Values{}
+	params.Set("version", membershipsAPIVersion)
+	params.Set("cascade", "true")
+
+	resp, err := g.client.DeleteWithContext(ctx, urlPath, params)
+	if err != nil {
+		return fmt.Errorf("Failed to remove group member; %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// MembershipCache holds the members of a group for a fixed time, so that a job which looks members up repeatedly
+// only fetches them once. It is safe for concurrent use.
+//
+//	cache := snyk.NewMembershipCache(group, 10*time.Minute)
+//	member, err := cache.MemberByEmail(ctx, "someone@example.com")
+type MembershipCache struct {
+	group Group
+	ttl   time.Duration
+	opts  GroupMembershipsOptions
+
+	mu      sync.Mutex
+	fetched bool
+	members []GroupMember
+	expires time.Time
+	// Incremented whenever the cached members change other than by a fetch
+	generation int
+}
+
+// NewMembershipCache creates a MembershipCache for the group whose members expire `ttl` after they are fetched. If
+// `opts` are given, they set what is fetched for each member.
+func NewMembershipCache(group Group, ttl time.Duration, opts ...GroupMembershipsOptions) *MembershipCache {
+	cache := &MembershipCache{group: group, ttl: ttl}
+	if len(opts) > 0 {
+		cache.opts = opts[0]
+	}
+	return cache
+}
+
+// Memberships gets the members of the group, fetching them if they aren't cached or have expired. The members are
+// copied, so changing them doesn't change the cache.
+//
+// The members are fetched without holding the cache's lock, so other lookups, RemoveMember and Invalidate aren't
+// blocked by the requests. Concurrent lookups of expired members may each fetch them.
+func (c *MembershipCache) Memberships(ctx context.Context) ([]GroupMember, error) {
+	c.mu.Lock()
+	if c.fetched && c.group.client.clock.Now().Before(c.expires) {
+		members := copyGroupMembers(c.members)
+		c.mu.Unlock()
+		return members, nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	members, err := c.group.MembershipsWithContext(ctx, c.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Members fetched before the cache was invalidated or a member was removed may be stale, so they aren't cached
+	if c.generation == generation {
+		c.fetched = true
+		c.members = members
+		c.expires = c.group.client.clock.Now().Add(c.ttl)
+	}
+
+	return copyGroupMembers(members), nil
+}
+
+// copyGroupMembers returns a deep copy of `members`, including their org roles
+func copyGroupMembers(members []GroupMember) []GroupMember {
+	copied := make([]GroupMember, len(members))
+	for i, member := range members {
+		if member.Orgs != nil {
+			member.Orgs = append([]GroupMemberOrg{}, member.Orgs...)
+		}
+		copied[i] = member
+	}
+	return copied
+}
+
+// Member gets the member of the group with the given user ID. ErrNotFound is returned if there isn't one.
+func (c *MembershipCache) Member(ctx context.Context, userID string) (GroupMember, error) {
+	return c.find(ctx, func(m GroupMember) bool { return m.UserID == userID }, "user "+userID)
+}
+
+// MemberByEmail gets the member of the group with the given email, ignoring case. ErrNotFound is returned if there
+// isn't one.
+func (c *MembershipCache) MemberByEmail(ctx context.Context, email string) (GroupMember, error) {
+	return c.find(ctx, func(m GroupMember) bool { return strings.EqualFold(m.Email, email) }, email)
+}
+
+func (c *MembershipCache) find(ctx context.Context, matches func(GroupMember) bool, description string) (GroupMember, error) {
+	members, err := c.Memberships(ctx)
+	if err != nil {
+		return GroupMember{}, err
+	}
+
+	for _, member := range members {
+		if matches(member) {
+			return member, nil
+		}
+	}
+
+	return GroupMember{}, fmt.Errorf("No group member found for %s; %w", description, ErrNotFound)
+}
+
+// RemoveMember removes the member from the group and from the cached members
+func (c *MembershipCache) RemoveMember(ctx context.Context, member GroupMember) error {
+	if err := c.group.RemoveMemberWithContext(ctx, member.MembershipID); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	members := []GroupMember{}
+	for _, m := range c.members {
+		if m.MembershipID != member.MembershipID {
+			members = append(members, m)
+		}
+	}
+	c.members = members
+	c.generation++
+	return nil
+}
+
+// Invalidate drops the cached members, so that they are fetched again on the next lookup
+func (c *MembershipCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fetched = false
+	c.members = nil
+	c.generation++
+}
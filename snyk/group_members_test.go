@@ -0,0 +1,279 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const testGroupMembershipID = "c4d5e6f7-a8b9-4c0d-8e1f-2a3b4c5d6e7f"
+
+func mockGroupMemberships() {
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/groups/%s/memberships", testGroupID)).
+		MatchParam("version", membershipsAPIVersion).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{
+					"type": "group_membership",
+					"id":   testGroupMembershipID,
+					"relationships": map[string]any{
+						"user": map[string]any{
+							"data": map[string]any{
+								"type": "user",
+								"id":   testUserID,
+								"attributes": map[string]any{
+									"name":     "Jo Bloggs",
+									"username": "jbloggs",
+									"email":    "jo@example.com",
+								},
+							},
+						},
+						"role": map[string]any{
+							"data": map[string]any{
+								"type":       "group_role",
+								"id":         "1f2e3d4c-5b6a-4978-8d6c-5b4a39281706",
+								"attributes": map[string]any{"name": "Group Member"},
+							},
+						},
+					},
+				},
+			},
+		})
+}
+
+func TestGroupMemberships(t *testing.T) {
+	defer gock.Off()
+
+	mockGroupMemberships()
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	members, err := group.Memberships()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []GroupMember{{
+		MembershipID: testGroupMembershipID,
+		UserID:       testUserID,
+		Name:         "Jo Bloggs",
+		Username:     "jbloggs",
+		Email:        "jo@example.com",
+		GroupRole:    "Group Member",
+		GroupRoleID:  "1f2e3d4c-5b6a-4978-8d6c-5b4a39281706",
+	}}, members)
+}
+
+func TestGroupMembershipsWithOrgRoles(t *testing.T) {
+	defer gock.Off()
+
+	mockGroupMemberships()
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/groups/%s/orgs", testGroupID)).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{"type": "org", "id": testOrgID, "attributes": map[string]any{"name": "org1", "slug": "org1"}},
+			},
+		})
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/memberships", testOrgID)).
+		MatchParam("version", membershipsAPIVersion).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{
+					"type": "org_membership",
+					"id":   "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b",
+					"relationships": map[string]any{
+						"user": map[string]any{"data": map[string]any{"type": "user", "id": testUserID}},
+						"role": map[string]any{
+							"data": map[string]any{
+								"type":       "org_role",
+								"id":         testOrgAdminRole,
+								"attributes": map[string]any{"name": "Org Admin"},
+							},
+						},
+					},
+				},
+			},
+		})
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	members, err := group.Memberships(GroupMembershipsOptions{IncludeOrgRoles: true})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 1, len(members))
+	assert.Equal(t, []GroupMemberOrg{{
+		OrgID:   testOrgID,
+		OrgName: "org1",
+		Role:    "Org Admin",
+		RoleID:  testOrgAdminRole,
+	}}, members[0].Orgs)
+}
+
+func TestGroupMembershipsWithOrgRolesFallsBackToV1(t *testing.T) {
+	defer gock.Off()
+
+	mockGroupMemberships()
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/groups/%s/orgs", testGroupID)).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{"type": "org", "id": testOrgID, "attributes": map[string]any{"name": "org1", "slug": "org1"}},
+			},
+		})
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/memberships", testOrgID)).
+		Reply(404)
+	mockOrgExists()
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/org/%s/members", testOrgID)).
+		MatchParam("includeGroupAdmins", "true").
+		Reply(200).
+		JSON([]map[string]string{
+			{"id": testUserID, "name": "Jo Bloggs", "username": "jbloggs", "email": "jo@example.com", "role": "admin"},
+		})
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	members, err := group.Memberships(GroupMembershipsOptions{IncludeOrgRoles: true})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 1, len(members))
+	assert.Equal(t, []GroupMemberOrg{{OrgID: testOrgID, OrgName: "org1", Role: "Org Admin"}}, members[0].Orgs)
+}
+
+func TestGroupRemoveMember(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/rest/groups/%s/memberships/%s", testGroupID, testGroupMembershipID)).
+		MatchParam("version", membershipsAPIVersion).
+		MatchParam("cascade", "true").
+		Reply(204)
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	err := group.RemoveMember(testGroupMembershipID)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestMembershipCache(t *testing.T) {
+	defer gock.Off()
+
+	clock := &fakeClock{now: time.Now()}
+	group := Group{ID: testGroupID, client: NewClient("mock-token", WithClock(clock))}
+	cache := NewMembershipCache(group, 10*time.Minute)
+	ctx := context.Background()
+
+	mockGroupMemberships()
+
+	member, err := cache.Member(ctx, testUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, "jo@example.com", member.Email)
+	assert.True(t, gock.IsDone())
+
+	// Lookups before the members expire don't send requests
+	member, err = cache.MemberByEmail(ctx, "JO@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, testUserID, member.UserID)
+	_, err = cache.MemberByEmail(ctx, "someone@example.com")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, gock.HasUnmatchedRequest())
+
+	// Once expired, the members are fetched again
+	mockGroupMemberships()
+	clock.now = clock.now.Add(10 * time.Minute)
+	_, err = cache.Memberships(ctx)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/rest/groups/%s/memberships/%s", testGroupID, testGroupMembershipID)).
+		Reply(204)
+
+	err = cache.RemoveMember(ctx, member)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	members, err := cache.Memberships(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(members))
+}
+
+func TestMembershipCacheCopiesOrgRoles(t *testing.T) {
+	defer gock.Off()
+
+	clock := &fakeClock{now: time.Now()}
+	group := Group{ID: testGroupID, client: NewClient("mock-token", WithClock(clock))}
+	cache := NewMembershipCache(group, 10*time.Minute, GroupMembershipsOptions{IncludeOrgRoles: true})
+	ctx := context.Background()
+
+	mockGroupMemberships()
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/groups/%s/orgs", testGroupID)).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{"type": "org", "id": testOrgID, "attributes": map[string]any{"name": "org1", "slug": "org1"}},
+			},
+		})
+	mockOrgMemberships("")
+
+	members, err := cache.Memberships(ctx)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	members[0].Orgs[0].Role = "Org Collaborator"
+
+	member, err := cache.Member(ctx, testUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Org Admin", member.Orgs[0].Role)
+}
+
+// invalidatingTransport invalidates the cache while the first request is in flight
+type invalidatingTransport struct {
+	cache       *MembershipCache
+	invalidated chan struct{}
+}
+
+func (t *invalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case <-t.invalidated:
+	default:
+		t.cache.Invalidate()
+		close(t.invalidated)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestMembershipCacheFetchesWithoutLocking(t *testing.T) {
+	defer gock.Off()
+
+	transport := &invalidatingTransport{invalidated: make(chan struct{})}
+	group := Group{ID: testGroupID, client: NewClient("mock-token", WithTransport(transport))}
+	cache := NewMembershipCache(group, 10*time.Minute)
+	transport.cache = cache
+	ctx := context.Background()
+
+	// Invalidating the cache during the fetch doesn't wait for it, and the members fetched aren't cached
+	mockGroupMemberships()
+	members, err := cache.Memberships(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(members))
+	assert.True(t, gock.IsDone())
+
+	mockGroupMemberships()
+	_, err = cache.Member(ctx, testUserID)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
@@ -13,7 +14,7 @@ func TestGroupGetAll(t *testing.T) {
 	respJSON, err := loadFixture("fixtures/group_get_all.json")
 	assert.NoError(t, err)
 
-	gock.New(baseURL).
+	gock.New(defaultBaseURL).
 		Get("/rest/groups").
 		MatchParam("version", defaultAPIVersion).
 		Reply(200).
@@ -33,7 +34,7 @@ func TestGroupGet(t *testing.T) {
 	respJSON, err := loadFixture("fixtures/group_get.json")
 	assert.NoError(t, err)
 
-	gock.New(baseURL).
+	gock.New(defaultBaseURL).
 		Get("/rest/groups/341bdf0c-05d3-47d4-b522-97ba9552b796").
 		MatchParam("version", defaultAPIVersion).
 		Reply(200).
@@ -45,3 +46,117 @@ func TestGroupGet(t *testing.T) {
 	assert.Equal(t, "Group1", group.Name)
 	assert.Equal(t, "341bdf0c-05d3-47d4-b522-97ba9552b796", group.ID)
 }
+
+func TestGroupOrgs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/groups/%s/orgs", testGroupID)).
+		MatchParam("version", defaultAPIVersion).
+		MatchParam("name", "^platform$").
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{"type": "org", "id": testOrgID, "attributes": map[string]any{"name": "Platform", "slug": "platform"}},
+			},
+		})
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	orgs, err := group.Orgs(ListOrgsOptions{Name: "platform"})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 1, len(orgs))
+	assert.Equal(t, "Platform", orgs[0].Name)
+	assert.Equal(t, testGroupID, orgs[0].GroupID)
+	assert.Equal(t, testOrgID, orgs[0].Projects.orgID)
+}
+
+func TestGroupSettings(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/group/%s/settings", testGroupID)).
+		Reply(200).
+		JSON(map[string]any{"sessionLength": 60, "requestAccess": map[string]bool{"enabled": true}})
+	gock.New(defaultBaseURL).
+		Put(fmt.Sprintf("/v1/group/%s/settings", testGroupID)).
+		JSON(map[string]any{"sessionLength": 120, "requestAccess": map[string]bool{"enabled": true}}).
+		Reply(200)
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	settings, err := group.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, 60, settings.SessionLength)
+	assert.True(t, *settings.RequestAccess.Enabled)
+
+	settings.SessionLength = 120
+	err = group.UpdateSettings(settings)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGroupUpdateSettingsPartially(t *testing.T) {
+	defer gock.Off()
+
+	// Only the session length is sent, so request access is left unchanged
+	gock.New(defaultBaseURL).
+		Put(fmt.Sprintf("/v1/group/%s/settings", testGroupID)).
+		JSON(map[string]any{"sessionLength": 120}).
+		Reply(200)
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	err := group.UpdateSettings(GroupSettings{SessionLength: 120})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGroupTags(t *testing.T) {
+	defer gock.Off()
+
+	firstPage := []Tag{}
+	for i := 0; i < groupTagsPageSize; i++ {
+		firstPage = append(firstPage, Tag{Key: "team", Value: fmt.Sprint(i)})
+	}
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/group/%s/tags", testGroupID)).
+		MatchParam("page", "1").
+		MatchParam("perPage", fmt.Sprint(groupTagsPageSize)).
+		Reply(200).
+		JSON(map[string]any{"tags": firstPage})
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/group/%s/tags", testGroupID)).
+		MatchParam("page", "2").
+		Reply(200).
+		JSON(map[string]any{"tags": []Tag{{Key: "env", Value: "prod"}}})
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	tags, err := group.Tags()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, groupTagsPageSize+1, len(tags))
+	assert.Equal(t, Tag{Key: "env", Value: "prod"}, tags[groupTagsPageSize])
+}
+
+func TestGroupDeleteTag(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/group/%s/tags/delete", testGroupID)).
+		JSON(map[string]any{"key": "team", "value": "legacy", "force": false}).
+		Reply(200)
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/group/%s/tags/delete", testGroupID)).
+		JSON(map[string]any{"key": "team", "value": "old", "force": true}).
+		Reply(200)
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	assert.NoError(t, group.DeleteTag(Tag{Key: "team", Value: "legacy"}))
+	assert.NoError(t, group.DeleteTag(Tag{Key: "team", Value: "old"}, DeleteTagOptions{Force: true}))
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,188 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	importJobPollInitialDelay = time.Second
+	importJobPollMaxDelay     = 30 * time.Second
+)
+
+// ImportStatus is the status of an import job, or of a single target within it
+type ImportStatus string
+
+// The statuses of import jobs reported by the Snyk API
+const (
+	ImportPending  ImportStatus = "pending"
+	ImportComplete ImportStatus = "complete"
+	ImportFailed   ImportStatus = "failed"
+)
+
+// ImportJob is an asynchronous import of targets into an org, created by Org.ImportProject
+type ImportJob struct {
+	ID string
+	// The API URL of the job, as returned in the Location header of the import request
+	URL    string
+	orgID  string
+	client *Client
+}
+
+// ImportJobStatus is the progress of an import job
+type ImportJobStatus struct {
+	ID      string       `json:"id"`
+	Status  ImportStatus `json:"status"`
+	Created time.Time    `json:"created"`
+	Logs    []ImportLog  `json:"logs"`
+}
+
+// ImportLog is the progress of importing a single target
+type ImportLog struct {
+	// The name of the target, e.g. `owner/repo`
+	Name     string            `json:"name"`
+	Created  time.Time         `json:"created"`
+	Status   ImportStatus      `json:"status"`
+	Projects []ImportedProject `json:"projects"`
+}
+
+// ImportedProject is a manifest file found while importing a target, and the project it was imported as
+type ImportedProject struct {
+	TargetFile string `json:"targetFile"`
+	Success    bool   `json:"success"`
+	ProjectURL string `json:"projectUrl"`
+	ProjectID  string `json:"projectId,omitempty"`
+}
+
+// ImportFailure is a target, or a manifest file within one, which could not be imported
+type ImportFailure struct {
+	// The name of the target, e.g. `owner/repo`
+	Target string
+	// The manifest file which failed to import. If empty, the whole target failed.
+	TargetFile string
+}
+
+// ImportResult is the outcome of a finished import job
+type ImportResult struct {
+	Status ImportStatus
+	Logs   []ImportLog
+	// The IDs of the projects which were created or updated by the import
+	ProjectIDs []string
+	// The projects which were created or updated by the import
+	Projects []Project
+	Failures []ImportFailure
+}
+
+// Status gets the current progress of the import job
+func (j *ImportJob) Status(ctx context.Context) (ImportJobStatus, error) {
+	resp, err := j.client.GetWithContext(ctx, j.URL, nil)
+	if err != nil {
+		return ImportJobStatus{}, fmt.Errorf("Failed to get import job status; %w", err)
+	}
+	defer resp.Body.Close()
+
+	status := ImportJobStatus{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return ImportJobStatus{}, fmt.Errorf("Failed to get import job status; %w", err)
+	}
+
+	return status, nil
+}
+
+// Wait polls the import job with increasing delays until it is no longer pending, then gets the projects it imported.
+// Cancelling `ctx` stops polling. Targets which failed to import are listed in the result's Failures rather than
+// returned as an error.
+func (j *ImportJob) Wait(ctx context.Context) (ImportResult, error) {
+	poll := newBackoff(j.client.clock, importJobPollInitialDelay, importJobPollMaxDelay)
+	for {
+		status, err := j.Status(ctx)
+		if err != nil {
+			return ImportResult{}, err
+		}
+
+		if status.Status != ImportPending {
+			return j.result(ctx, status)
+		}
+
+		if err := poll.wait(ctx); err != nil {
+			return ImportResult{}, fmt.Errorf("Failed to wait for import job; %w", err)
+		}
+	}
+}
+
+func (j *ImportJob) result(ctx context.Context, status ImportJobStatus) (ImportResult, error) {
+	result := ImportResult{
+		Status:     status.Status,
+		Logs:       status.Logs,
+		ProjectIDs: []string{},
+		Projects:   []Project{},
+		Failures:   []ImportFailure{},
+	}
+
+	for _, log := range status.Logs {
+		if log.Status == ImportFailed && len(log.Projects) == 0 {
+			result.Failures = append(result.Failures, ImportFailure{Target: log.Name})
+		}
+		for _, project := range log.Projects {
+			if !project.Success {
+				result.Failures = append(result.Failures, ImportFailure{Target: log.Name, TargetFile: project.TargetFile})
+				continue
+			}
+			if id := project.id(); id != "" && !isInSlice(id, result.ProjectIDs) {
+				result.ProjectIDs = append(result.ProjectIDs, id)
+			}
+		}
+	}
+
+	projects := ProjectsService{client: j.client, orgID: j.orgID}
+	for _, id := range result.ProjectIDs {
+		project, err := projects.GetWithContext(ctx, id)
+		if err != nil {
+			return result, fmt.Errorf("Failed to get imported project %s; %w", id, err)
+		}
+		result.Projects = append(result.Projects, project)
+	}
+
+	return result, nil
+}
+
+// id returns the ID of the imported project, falling back to the last segment of its URL
+func (p ImportedProject) id() string {
+	if p.ProjectID != "" {
+		return p.ProjectID
+	}
+	if p.ProjectURL == "" {
+		return ""
+	}
+	projectURL, err := url.Parse(p.ProjectURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(strings.TrimSuffix(projectURL.Path, "/"))
+}
+
+// newImportJob creates an ImportJob from the Location header returned by an import request
+func newImportJob(client *Client, orgID, location string) (ImportJob, error) {
+	if location == "" {
+		return ImportJob{}, errors.New("the import response has no Location header")
+	}
+
+	jobURL, err := url.Parse(location)
+	if err != nil {
+		return ImportJob{}, fmt.Errorf("the import job URL is invalid; %w", err)
+	}
+
+	return ImportJob{
+		ID:     path.Base(strings.TrimSuffix(jobURL.Path, "/")),
+		URL:    location,
+		orgID:  orgID,
+		client: client,
+	}, nil
+}
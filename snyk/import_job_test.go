@@ -0,0 +1,139 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const (
+	testIntegrationID = "9a3e5d90-b782-468a-a042-9a2073736f0b"
+	testImportJobID   = "dce061f7-ce0f-4ccf-b49b-4335d1205bd9"
+)
+
+func mockImport() string {
+	jobPath := fmt.Sprintf("/v1/org/%s/integrations/%s/import/%s", testOrgID, testIntegrationID, testImportJobID)
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/org/%s/integrations/%s/import", testOrgID, testIntegrationID)).
+		JSON(map[string]any{"target": map[string]any{"owner": "snyk", "name": "goof", "branch": "main"}}).
+		Reply(201).
+		SetHeader("Location", "https://api.snyk.io"+jobPath)
+
+	return jobPath
+}
+
+func testImportTarget() ImportTarget {
+	target := ImportTarget{}
+	target.Target.Owner = "snyk"
+	target.Target.Name = "goof"
+	target.Target.Branch = "main"
+	return target
+}
+
+func TestOrgImportProject(t *testing.T) {
+	defer gock.Off()
+
+	respJSON, err := loadFixture("fixtures/project_update.json")
+	assert.NoError(t, err)
+
+	jobPath := mockImport()
+	gock.New(defaultBaseURL).
+		Get(jobPath).
+		Times(2).
+		Reply(200).
+		JSON(map[string]any{"id": testImportJobID, "status": "pending", "created": "2023-07-23T15:21:10.611Z"})
+	gock.New(defaultBaseURL).
+		Get(jobPath).
+		Reply(200).
+		JSON(map[string]any{
+			"id":      testImportJobID,
+			"status":  "complete",
+			"created": "2023-07-23T15:21:10.611Z",
+			"logs": []map[string]any{
+				{
+					"name":    "snyk/goof",
+					"created": "2023-07-23T15:21:10.643Z",
+					"status":  "complete",
+					"projects": []map[string]any{
+						{
+							"targetFile": "package.json",
+							"success":    true,
+							"projectUrl": "https://app.snyk.io/org/test-org/project/" + testProjectID,
+						},
+						{"targetFile": "api/requirements.txt", "success": false},
+					},
+				},
+				{"name": "snyk/missing", "created": "2023-07-23T15:21:10.643Z", "status": "failed"},
+			},
+		})
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects/%s", testOrgID, testProjectID)).
+		Reply(200).
+		JSON(respJSON)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+	org := Org{ID: testOrgID, client: client}
+
+	job, err := org.ImportProject(testIntegrationID, testImportTarget())
+	assert.NoError(t, err)
+	assert.Equal(t, testImportJobID, job.ID)
+
+	result, err := job.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.sleeps)
+
+	assert.Equal(t, ImportComplete, result.Status)
+	assert.Equal(t, 2, len(result.Logs))
+	assert.Equal(t, []string{testProjectID}, result.ProjectIDs)
+	assert.Equal(t, 1, len(result.Projects))
+	assert.Equal(t, "snyk/goof:package.json", result.Projects[0].Name)
+	assert.Equal(t, []ImportFailure{
+		{Target: "snyk/goof", TargetFile: "api/requirements.txt"},
+		{Target: "snyk/missing"},
+	}, result.Failures)
+}
+
+func TestOrgImportProjectWithoutLocation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/org/%s/integrations/%s/import", testOrgID, testIntegrationID)).
+		Reply(201)
+
+	org := Org{ID: testOrgID, client: NewClient("mock-token")}
+
+	_, err := org.ImportProject(testIntegrationID, testImportTarget())
+	assert.Error(t, err)
+}
+
+func TestImportJobWaitCancelled(t *testing.T) {
+	defer gock.Off()
+
+	jobPath := mockImport()
+	gock.New(defaultBaseURL).
+		Get(jobPath).
+		Reply(200).
+		JSON(map[string]any{"id": testImportJobID, "status": "pending"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &cancellingClock{fakeClock: fakeClock{now: time.Now()}, cancel: cancel}
+	client := NewClient("mock-token", WithClock(clock))
+	org := Org{ID: testOrgID, client: client}
+
+	job, err := org.ImportProject(testIntegrationID, testImportTarget())
+	assert.NoError(t, err)
+
+	_, err = job.Wait(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, gock.IsDone())
+}
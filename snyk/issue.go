@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -106,12 +107,17 @@ type ProjectIssuesService struct {
 
 // GetAll gets all issues for the given project
 func (s *ProjectIssuesService) GetAll() ([]Issue, error) {
+	return s.GetAllWithContext(context.Background())
+}
+
+// GetAllWithContext gets all issues for the given project using the provided context
+func (s *ProjectIssuesService) GetAllWithContext(ctx context.Context) ([]Issue, error) {
 	var issues []Issue
 	path := fmt.Sprintf("/v1/org/%s/project/%s/aggregated-issues", s.orgID, s.projectID)
 	params := url.Values{}
 	params.Set("includeIntroducedThrough", "true")
 	params.Set("includeDescription", "true")
-	resp, err := s.client.Post(path, params, nil)
+	resp, err := s.client.PostWithContext(ctx, path, params, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -42,6 +43,11 @@ func (r *resource) intoIssueDetails() IssueDetails {
 
 // GetDetails provides additional information about an issue
 func (i *IssueV2) GetDetails() (IssueDetails, error) {
+	return i.GetDetailsWithContext(context.Background())
+}
+
+// GetDetailsWithContext provides additional information about an issue using the provided context
+func (i *IssueV2) GetDetailsWithContext(ctx context.Context) (IssueDetails, error) {
 	var path string
 	params := url.Values{}
 
@@ -54,7 +60,7 @@ func (i *IssueV2) GetDetails() (IssueDetails, error) {
 		return IssueDetails{}, fmt.Errorf("GetIssueDetails is not yet implemented for issues of type %s", i.Type)
 	}
 
-	res, err := getSingleResource(i.client, path, params)
+	res, err := getSingleResource(ctx, i.client, path, params)
 	if err != nil {
 		return IssueDetails{}, err
 	}
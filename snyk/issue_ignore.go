@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -50,9 +51,14 @@ type IgnoredIssues map[string][]Ignore
 
 // GetIgnored gets all ignored issues
 func (s *ProjectIssuesService) GetIgnored() (IgnoredIssues, error) {
+	return s.GetIgnoredWithContext(context.Background())
+}
+
+// GetIgnoredWithContext gets all ignored issues using the provided context
+func (s *ProjectIssuesService) GetIgnoredWithContext(ctx context.Context) (IgnoredIssues, error) {
 	path := fmt.Sprintf("/v1/org/%s/project/%s/ignores", s.orgID, s.projectID)
 
-	resp, err := s.client.Get(path, nil)
+	resp, err := s.client.GetWithContext(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -84,9 +90,14 @@ func (s *ProjectIssuesService) GetIgnored() (IgnoredIssues, error) {
 
 // GetIgnore gets the ignore data for the issue
 func (i *Issue) GetIgnore() (Ignore, error) {
+	return i.GetIgnoreWithContext(context.Background())
+}
+
+// GetIgnoreWithContext gets the ignore data for the issue using the provided context
+func (i *Issue) GetIgnoreWithContext(ctx context.Context) (Ignore, error) {
 	path := fmt.Sprintf("/v1/org/%s/project/%s/ignore/%s", i.orgID, i.projectID, i.ID)
 
-	resp, err := i.client.Get(path, nil)
+	resp, err := i.client.GetWithContext(ctx, path, nil)
 	if err != nil {
 		return Ignore{}, err
 	}
@@ -122,32 +133,47 @@ type IgnoreOptions struct {
 
 // AddIgnore adds an ignores for the specified issue according to `IgnoreOptions`
 func (i *Issue) AddIgnore(opts IgnoreOptions) error {
+	return i.AddIgnoreWithContext(context.Background(), opts)
+}
+
+// AddIgnoreWithContext adds an ignores for the specified issue according to `IgnoreOptions` using the provided context
+func (i *Issue) AddIgnoreWithContext(ctx context.Context, opts IgnoreOptions) error {
 	if opts.ReasonType != "not-vulnerable" && opts.ReasonType != "wont-fix" && opts.ReasonType != "temporary-ignore" {
 		return errors.New("ReasonType must be one of \"not-vulnerable\", \"wont-fix\", \"temporary-ignore\"")
 	}
 
 	path := fmt.Sprintf("v1/org/%s/project/%s/ignore/%s", i.orgID, i.projectID, i.ID)
 
-	_, err := i.client.Post(path, nil, opts)
+	_, err := i.client.PostWithContext(ctx, path, nil, opts)
 	return err
 }
 
 // ReplaceIgnore replaces an existing ignore with the new ignore
 func (i *Issue) ReplaceIgnore(opts IgnoreOptions) error {
+	return i.ReplaceIgnoreWithContext(context.Background(), opts)
+}
+
+// ReplaceIgnoreWithContext replaces an existing ignore with the new ignore using the provided context
+func (i *Issue) ReplaceIgnoreWithContext(ctx context.Context, opts IgnoreOptions) error {
 	if opts.ReasonType != "not-vulnerable" && opts.ReasonType != "wont-fix" && opts.ReasonType != "temporary-ignore" {
 		return errors.New("ReasonType must be one of \"not-vulnerable\", \"wont-fix\", \"temporary-ignore\"")
 	}
 
 	path := fmt.Sprintf("v1/org/%s/project/%s/ignore/%s", i.orgID, i.projectID, i.ID)
 
-	_, err := i.client.Put(path, opts)
+	_, err := i.client.PutWithContext(ctx, path, opts)
 	return err
 }
 
 // DeleteIgnore deletes ignores for a given issue
 func (i *Issue) DeleteIgnore() error {
+	return i.DeleteIgnoreWithContext(context.Background())
+}
+
+// DeleteIgnoreWithContext deletes ignores for a given issue using the provided context
+func (i *Issue) DeleteIgnoreWithContext(ctx context.Context) error {
 	path := fmt.Sprintf("v1/org/%s/project/%s/ignore/%s", i.orgID, i.projectID, i.ID)
 
-	_, err := i.client.Delete(path, nil)
+	_, err := i.client.DeleteWithContext(ctx, path, nil)
 	return err
 }
@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"time"
@@ -67,8 +68,7 @@ func (i *IssueV2) intoIssueV1() Issue {
 	}
 }
 
-func getAllV2Issues(client *Client, orgID string, projectID *string) ([]IssueV2, error) {
-	var issues []IssueV2
+func newV2IssuesPager(client *Client, orgID string, projectID *string, opts PageOptions) *Pager[IssueV2] {
 	path := fmt.Sprintf("/rest/orgs/%s/issues", orgID)
 	params := url.Values{}
 	params.Add("version", "2024-05-23~beta")
@@ -76,48 +76,82 @@ func getAllV2Issues(client *Client, orgID string, projectID *string) ([]IssueV2,
 		params.Add("scan_item.type", "project")
 		params.Add("scan_item.id", *projectID)
 	}
-	resources, err := getMultiResource(client, path, params)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, r := range resources {
-		issues = append(issues, r.intoIssueV2(client))
-	}
 
-	return issues, nil
+	return newPager(client, path, params, opts, func(r resource) IssueV2 {
+		return r.intoIssueV2(client)
+	})
 }
 
 // GetAllV2 gets all IssueV2s for the org.
 func (s *OrgIssuesService) GetAllV2() ([]IssueV2, error) {
-	return getAllV2Issues(s.client, s.orgID, nil)
+	return s.GetAllV2WithContext(context.Background())
+}
+
+// GetAllV2WithContext gets all IssueV2s for the org using the provided context
+func (s *OrgIssuesService) GetAllV2WithContext(ctx context.Context) ([]IssueV2, error) {
+	return collectPages(ctx, s.PagerV2(PageOptions{}))
+}
+
+// PagerV2 returns a Pager which fetches all IssueV2s for the org one page at a time
+func (s *OrgIssuesService) PagerV2(opts PageOptions) *Pager[IssueV2] {
+	return newV2IssuesPager(s.client, s.orgID, nil, opts)
 }
 
 // GetAllV2 gets all IssueV2s for the project
 func (s *ProjectIssuesService) GetAllV2() ([]IssueV2, error) {
-	return getAllV2Issues(s.client, s.orgID, &s.projectID)
+	return s.GetAllV2WithContext(context.Background())
+}
+
+// GetAllV2WithContext gets all IssueV2s for the project using the provided context
+func (s *ProjectIssuesService) GetAllV2WithContext(ctx context.Context) ([]IssueV2, error) {
+	return collectPages(ctx, s.PagerV2(PageOptions{}))
+}
+
+// PagerV2 returns a Pager which fetches all IssueV2s for the project one page at a time
+func (s *ProjectIssuesService) PagerV2(opts PageOptions) *Pager[IssueV2] {
+	return newV2IssuesPager(s.client, s.orgID, &s.projectID, opts)
 }
 
 // GetIgnore gets the ignore data for the issue
 func (i *IssueV2) GetIgnore() (Ignore, error) {
+	return i.GetIgnoreWithContext(context.Background())
+}
+
+// GetIgnoreWithContext gets the ignore data for the issue using the provided context
+func (i *IssueV2) GetIgnoreWithContext(ctx context.Context) (Ignore, error) {
 	issueV1 := i.intoIssueV1()
-	return issueV1.GetIgnore()
+	return issueV1.GetIgnoreWithContext(ctx)
 }
 
 // AddIgnore adds an ignores for the specified issue according to `IgnoreOptions`
 func (i *IssueV2) AddIgnore(opts IgnoreOptions) error {
+	return i.AddIgnoreWithContext(context.Background(), opts)
+}
+
+// AddIgnoreWithContext adds an ignores for the specified issue according to `IgnoreOptions` using the provided context
+func (i *IssueV2) AddIgnoreWithContext(ctx context.Context, opts IgnoreOptions) error {
 	issueV1 := i.intoIssueV1()
-	return issueV1.AddIgnore(opts)
+	return issueV1.AddIgnoreWithContext(ctx, opts)
 }
 
 // ReplaceIgnore replaces an existing ignore with the new ignore
 func (i *IssueV2) ReplaceIgnore(opts IgnoreOptions) error {
+	return i.ReplaceIgnoreWithContext(context.Background(), opts)
+}
+
+// ReplaceIgnoreWithContext replaces an existing ignore with the new ignore using the provided context
+func (i *IssueV2) ReplaceIgnoreWithContext(ctx context.Context, opts IgnoreOptions) error {
 	issueV1 := i.intoIssueV1()
-	return issueV1.ReplaceIgnore(opts)
+	return issueV1.ReplaceIgnoreWithContext(ctx, opts)
 }
 
 // DeleteIgnore deletes ignores for a given issue
 func (i *IssueV2) DeleteIgnore() error {
+	return i.DeleteIgnoreWithContext(context.Background())
+}
+
+// DeleteIgnoreWithContext deletes ignores for a given issue using the provided context
+func (i *IssueV2) DeleteIgnoreWithContext(ctx context.Context) error {
 	issueV1 := i.intoIssueV1()
-	return issueV1.DeleteIgnore()
+	return issueV1.DeleteIgnoreWithContext(ctx)
 }
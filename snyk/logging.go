@@ -0,0 +1,67 @@
+package snyk
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// discardHandler is the slog.Handler used when no logger is set, so that the client writes no logs by default
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// redactToken replaces any occurrence of the API token in `s`. The token is redacted both with and without its
+// `token ` or `Bearer ` prefix.
+func redactToken(s string, token string) string {
+	if token == "" {
+		return s
+	}
+
+	s = strings.ReplaceAll(s, token, redacted)
+
+	if _, secret, ok := strings.Cut(token, " "); ok && secret != "" {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+
+	return s
+}
+
+// logAttempt logs the outcome of a single attempt at sending `req`. `retryDelay` is only used when `retry` is true.
+func (c *Client) logAttempt(ctx context.Context, req *http.Request, resp *http.Response, err error, attempt int, latency time.Duration, retry bool, retryDelay time.Duration) {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.String("request_id", resp.Header.Get("snyk-request-id")),
+		)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactToken(err.Error(), c.token)))
+	}
+
+	switch {
+	case retry:
+		attrs = append(attrs, slog.Duration("retry_in", retryDelay))
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "retrying snyk request", attrs...)
+	case err != nil:
+		c.logger.LogAttrs(ctx, slog.LevelError, "snyk request failed", attrs...)
+	case resp.StatusCode >= 400:
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "snyk request returned an error", attrs...)
+	default:
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "snyk request", attrs...)
+	}
+}
@@ -0,0 +1,42 @@
+package snyk
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func TestLoggingRedactsToken(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		Reply(401).
+		SetHeader("snyk-request-id", "request-1").
+		JSON(map[string]any{"message": "Invalid auth token provided: token secret-token"})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("token secret-token", WithLogger(logger))
+
+	_, err := client.Get("/rest/orgs", nil)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+	assert.Contains(t, err.Error(), redacted)
+
+	assert.Contains(t, logs.String(), `"path":"/rest/orgs"`)
+	assert.Contains(t, logs.String(), `"status":401`)
+	assert.Contains(t, logs.String(), `"request_id":"request-1"`)
+	assert.NotContains(t, logs.String(), "secret-token")
+}
+
+func TestRedactToken(t *testing.T) {
+	assert.Equal(t, "auth: [REDACTED]", redactToken("auth: token abc123", "token abc123"))
+	assert.Equal(t, "key [REDACTED]", redactToken("key abc123", "token abc123"))
+	assert.Equal(t, "nothing", redactToken("nothing", ""))
+	assert.False(t, strings.Contains(redactToken("Bearer xyz", "Bearer xyz"), "xyz"))
+}
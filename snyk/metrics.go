@@ -0,0 +1,147 @@
+package snyk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram buckets used by MetricsObserver
+var DefaultLatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// EndpointMetrics are the metrics recorded for a single endpoint
+type EndpointMetrics struct {
+	// The number of attempts sent to the endpoint, including retries
+	Requests int
+	// The number of attempts which failed with a transport error or an error status code
+	Errors int
+	// The number of attempts by response status code. Transport errors are counted under 0.
+	StatusCodes map[int]int
+	Retries     int
+	Pages       int
+	Items       int
+	// The total latency of all attempts
+	TotalLatency time.Duration
+	// The number of attempts whose latency was at most the corresponding bucket in `LatencyBuckets`. The final count
+	// is for attempts which took longer than every bucket.
+	LatencyCounts  []int
+	LatencyBuckets []time.Duration
+}
+
+// MetricsObserver is an Observer which records request counts, errors and latency histograms for each endpoint. The
+// endpoints are keyed by method and path template, e.g. `GET /rest/orgs/{org_id}/projects`. Use Snapshot to read the
+// metrics, e.g. when exporting them to a metrics collector.
+type MetricsObserver struct {
+	NoopObserver
+	buckets []time.Duration
+
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+// NewMetricsObserver creates a MetricsObserver which records latencies using the given histogram bucket upper bounds.
+// If no buckets are passed, DefaultLatencyBuckets is used. The buckets are copied, so changing them afterwards doesn't
+// affect the observer.
+func NewMetricsObserver(buckets ...time.Duration) *MetricsObserver {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	return &MetricsObserver{
+		buckets:   append([]time.Duration(nil), buckets...),
+		endpoints: map[string]*EndpointMetrics{},
+	}
+}
+
+// endpoint returns the metrics for the given key, creating them if needed. The caller must hold `m.mu`.
+func (m *MetricsObserver) endpoint(key string) *EndpointMetrics {
+	metrics, ok := m.endpoints[key]
+	if !ok {
+		metrics = &EndpointMetrics{
+			StatusCodes:    map[int]int{},
+			LatencyCounts:  make([]int, len(m.buckets)+1),
+			LatencyBuckets: m.buckets,
+		}
+		m.endpoints[key] = metrics
+	}
+	return metrics
+}
+
+// RequestFinish implements Observer
+func (m *MetricsObserver) RequestFinish(_ context.Context, result RequestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := m.endpoint(result.Method + " " + result.PathTemplate)
+	metrics.Requests++
+	metrics.StatusCodes[result.StatusCode]++
+	if result.Err != nil || result.StatusCode >= 400 {
+		metrics.Errors++
+	}
+
+	metrics.TotalLatency += result.Duration
+	bucket := len(m.buckets)
+	for i, upperBound := range m.buckets {
+		if result.Duration <= upperBound {
+			bucket = i
+			break
+		}
+	}
+	metrics.LatencyCounts[bucket]++
+}
+
+// Retry implements Observer
+func (m *MetricsObserver) Retry(_ context.Context, info RequestInfo, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoint(info.Method+" "+info.PathTemplate).Retries++
+}
+
+// PageFetched implements Observer
+func (m *MetricsObserver) PageFetched(_ context.Context, page PageInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := m.endpoint("GET " + page.PathTemplate)
+	metrics.Pages++
+	metrics.Items += page.Items
+}
+
+// Snapshot returns a copy of the metrics recorded so far, keyed by method and path template
+func (m *MetricsObserver) Snapshot() map[string]EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]EndpointMetrics, len(m.endpoints))
+	for key, metrics := range m.endpoints {
+		metricsCopy := *metrics
+		metricsCopy.StatusCodes = make(map[int]int, len(metrics.StatusCodes))
+		for code, count := range metrics.StatusCodes {
+			metricsCopy.StatusCodes[code] = count
+		}
+		metricsCopy.LatencyCounts = append([]int(nil), metrics.LatencyCounts...)
+		metricsCopy.LatencyBuckets = append([]time.Duration(nil), metrics.LatencyBuckets...)
+		snapshot[key] = metricsCopy
+	}
+
+	return snapshot
+}
+
+// Reset clears all recorded metrics
+func (m *MetricsObserver) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoints = map[string]*EndpointMetrics{}
+}
@@ -0,0 +1,90 @@
+package snyk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func TestMetricsObserver(t *testing.T) {
+	defer gock.Off()
+
+	respJSONp1, err := loadFixture("fixtures/container_image_get_all_page1.json")
+	assert.NoError(t, err)
+
+	respJSONp2, err := loadFixture("fixtures/container_image_get_all_page2.json")
+	assert.NoError(t, err)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		Reply(500)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		Reply(200).
+		JSON(respJSONp1)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		MatchParam("starting_after", "v2.mXj4PTbNK6N_N7K3lcYwjZvuYLBeeSCSNfK9EznyHAo=").
+		Reply(200).
+		JSON(respJSONp2)
+
+	metrics := NewMetricsObserver()
+	client := NewClient("mock-token", WithObserver(metrics), WithClock(&fakeClock{now: time.Now()}))
+	org := Org{ID: testOrgID, ContainerImages: ContainerImagesService{client: client, orgID: testOrgID}}
+
+	_, err = org.ContainerImages.GetAll()
+	assert.NoError(t, err)
+
+	snapshot := metrics.Snapshot()
+	endpoint, ok := snapshot["GET /rest/orgs/{org_id}/container_images"]
+	assert.True(t, ok)
+	assert.Equal(t, 3, endpoint.Requests)
+	assert.Equal(t, 1, endpoint.Errors)
+	assert.Equal(t, 1, endpoint.Retries)
+	assert.Equal(t, 2, endpoint.Pages)
+	assert.Equal(t, 12, endpoint.Items)
+	assert.Equal(t, map[int]int{200: 2, 500: 1}, endpoint.StatusCodes)
+	assert.Equal(t, 3, endpoint.LatencyCounts[0])
+}
+
+func TestMetricsObserverCopiesBuckets(t *testing.T) {
+	defaults := append([]time.Duration(nil), DefaultLatencyBuckets...)
+	defer func() { DefaultLatencyBuckets = defaults }()
+
+	metrics := NewMetricsObserver()
+	DefaultLatencyBuckets[0] = time.Hour
+
+	metrics.RequestFinish(context.Background(), RequestResult{
+		RequestInfo: RequestInfo{Method: "GET", PathTemplate: "/rest/self"},
+		StatusCode:  200,
+		Duration:    time.Second,
+	})
+
+	endpoint := metrics.Snapshot()["GET /rest/self"]
+	assert.Equal(t, defaults, endpoint.LatencyBuckets)
+	assert.Equal(t, 0, endpoint.LatencyCounts[0])
+	endpoint.LatencyBuckets[0] = time.Hour
+	assert.Equal(t, defaults, metrics.Snapshot()["GET /rest/self"].LatencyBuckets)
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/rest/orgs/8bcff720-99a4-4442-bb35-31f7a74d27b0/projects":                       "/rest/orgs/{org_id}/projects",
+		"rest/orgs/8bcff720-99a4-4442-bb35-31f7a74d27b0/issues/detail/code/abc123":       "/rest/orgs/{org_id}/issues/detail/code/{issue_id}",
+		"/v1/org/8bcff720-99a4-4442-bb35-31f7a74d27b0/project/1234/ignore/SNYK-JS-1?x=1": "/v1/org/{org_id}/project/{project_id}/ignore/{issue_id}",
+		"/rest/groups": "/rest/groups",
+	}
+
+	for path, expected := range tests {
+		assert.Equal(t, expected, PathTemplate(path))
+	}
+}
@@ -0,0 +1,143 @@
+package snyk
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RequestInfo describes a single attempt at sending a request
+type RequestInfo struct {
+	Method string
+	// The request path, e.g. `/rest/orgs/8bcff720-99a4-4442-bb35-31f7a74d27b0/projects`
+	Path string
+	// The request path with IDs replaced by placeholders, e.g. `/rest/orgs/{org_id}/projects`
+	PathTemplate string
+	// The number of retries already made for the request. This is 0 for the first attempt.
+	Attempt int
+}
+
+// RequestResult describes the outcome of a single attempt at sending a request
+type RequestResult struct {
+	RequestInfo
+	// The response status code. This is 0 if the request failed with a transport error.
+	StatusCode int
+	// The value of the `snyk-request-id` response header
+	RequestID string
+	Duration  time.Duration
+	// The transport error returned when sending the request, if any
+	Err error
+}
+
+// PageInfo describes a page of results fetched while paginating a list endpoint
+type PageInfo struct {
+	Path         string
+	PathTemplate string
+	// The number of the page, starting at 1
+	Page int
+	// The number of items in the page
+	Items int
+}
+
+// Observer receives events about the requests sent by a Client. It can be used to record metrics or traces. Observer
+// methods are called synchronously, so they should return quickly.
+type Observer interface {
+	// RequestStart is called before each attempt at sending a request. The returned context is used for the attempt,
+	// which allows a span to be started for it.
+	RequestStart(ctx context.Context, info RequestInfo) context.Context
+	// RequestFinish is called after each attempt with the context returned by RequestStart
+	RequestFinish(ctx context.Context, result RequestResult)
+	// Retry is called when a failed attempt is going to be retried after `delay`
+	Retry(ctx context.Context, info RequestInfo, delay time.Duration)
+	// PageFetched is called after each page of a list endpoint has been fetched
+	PageFetched(ctx context.Context, page PageInfo)
+}
+
+// NoopObserver is an Observer which does nothing. It can be embedded in types which only implement some Observer
+// methods.
+type NoopObserver struct{}
+
+// RequestStart implements Observer
+func (NoopObserver) RequestStart(ctx context.Context, _ RequestInfo) context.Context { return ctx }
+
+// RequestFinish implements Observer
+func (NoopObserver) RequestFinish(context.Context, RequestResult) {}
+
+// Retry implements Observer
+func (NoopObserver) Retry(context.Context, RequestInfo, time.Duration) {}
+
+// PageFetched implements Observer
+func (NoopObserver) PageFetched(context.Context, PageInfo) {}
+
+// multiObserver sends events to each of its observers in order
+type multiObserver []Observer
+
+func (m multiObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, o := range m {
+		ctx = o.RequestStart(ctx, info)
+	}
+	return ctx
+}
+
+func (m multiObserver) RequestFinish(ctx context.Context, result RequestResult) {
+	for _, o := range m {
+		o.RequestFinish(ctx, result)
+	}
+}
+
+func (m multiObserver) Retry(ctx context.Context, info RequestInfo, delay time.Duration) {
+	for _, o := range m {
+		o.Retry(ctx, info, delay)
+	}
+}
+
+func (m multiObserver) PageFetched(ctx context.Context, page PageInfo) {
+	for _, o := range m {
+		o.PageFetched(ctx, page)
+	}
+}
+
+// pathParamNames maps a path segment to the name of the ID which follows it
+var pathParamNames = map[string]string{
+	"orgs":             "org_id",
+	"org":              "org_id",
+	"groups":           "group_id",
+	"group":            "group_id",
+	"projects":         "project_id",
+	"project":          "project_id",
+	"targets":          "target_id",
+	"container_images": "image_id",
+	"issues":           "issue_id",
+	"issue":            "issue_id",
+	"ignore":           "issue_id",
+	"code":             "issue_id",
+	"integrations":     "integration_id",
+	"members":          "user_id",
+	"update":           "user_id",
+	"memberships":      "membership_id",
+	"invites":          "invite_id",
+	"users":            "user_id",
+}
+
+// PathTemplate replaces the IDs in a Snyk API path with placeholders named after the resource they identify, so that
+// `/rest/orgs/8bcff720-99a4-4442-bb35-31f7a74d27b0/projects` becomes `/rest/orgs/{org_id}/projects`. Any segment after
+// the API prefix which contains a digit is treated as an ID.
+func PathTemplate(urlPath string) string {
+	urlPath = strings.SplitN(urlPath, "?", 2)[0]
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+
+	for i, segment := range segments {
+		if i == 0 || !strings.ContainsFunc(segment, unicode.IsDigit) {
+			continue
+		}
+
+		name, ok := pathParamNames[segments[i-1]]
+		if !ok {
+			name = "id"
+		}
+		segments[i] = "{" + name + "}"
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,173 @@
+package snyk
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Region is a named Snyk deployment. Each region is served from its own API domain.
+type Region string
+
+const (
+	// RegionUS is the default multi-tenant Snyk region hosted in the US
+	RegionUS Region = "US"
+	// RegionEU is the multi-tenant Snyk region hosted in the EU
+	RegionEU Region = "EU"
+	// RegionAU is the multi-tenant Snyk region hosted in Australia
+	RegionAU Region = "AU"
+)
+
+var regionBaseURLs = map[Region]string{
+	RegionUS: "https://api.snyk.io/",
+	RegionEU: "https://api.eu.snyk.io/",
+	RegionAU: "https://api.au.snyk.io/",
+}
+
+// ClientOption configures a Client when passed to NewClient
+type ClientOption func(*Client)
+
+// WithBaseURL sets the base URL that request paths are joined to. Use this for self-hosted or single-tenant
+// deployments of Snyk, or to point the client at a test server. The URL may include a path prefix.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithRegion sets the base URL to the API domain of the given Snyk region. Unknown regions are ignored. For
+// self-hosted deployments, use WithBaseURL instead.
+func WithRegion(region Region) ClientOption {
+	return func(c *Client) {
+		if regionURL, ok := regionBaseURLs[region]; ok {
+			c.baseURL = regionURL
+		}
+	}
+}
+
+// WithHTTPClient sets the `*http.Client` used to send requests (default = `http.DefaultClient`)
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTransport sets the `http.RoundTripper` used to send requests. The client's `*http.Client` is copied before the
+// transport is set so that a shared client such as `http.DefaultClient` is never modified.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAPIVersion sets the default version of the REST API used by requests that don't specify their own version
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.APIVersion = version
+	}
+}
+
+// WithMaxRetries sets how many times a failed request will be retried before returning an error. See SetMaxRetries.
+func WithMaxRetries(retries int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = retries
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy which decides whether failed requests are retried (default =
+// DefaultRetryPolicy())
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy != nil {
+			c.retryPolicy = policy
+		}
+	}
+}
+
+// WithClock sets the Clock used to wait between retries. This is mostly useful in tests.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithRESTRateLimit limits the rate of requests sent to the REST API (paths starting with `/rest/`)
+func WithRESTRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter.rest = newTokenBucket(limit)
+	}
+}
+
+// WithV1RateLimit limits the rate of requests sent to the v1 API
+func WithV1RateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter.v1 = newTokenBucket(limit)
+	}
+}
+
+// WithPathRateLimit limits the rate of requests sent to paths matching `pattern`, in addition to the REST or v1 limit.
+// Patterns use the syntax of `path.Match`, e.g. `/v1/group/*/members`. By default, `/v1/group/*/members` is limited
+// to 1 request per minute. Passing an empty RateLimit removes the limit for the pattern.
+func WithPathRateLimit(pattern string, limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter.setPathLimit(pattern, limit)
+	}
+}
+
+// WithMaxConcurrentRequests limits how many requests can be in flight at once. Requests over the limit wait until
+// another request has received its response.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.rateLimiter.inFlight = make(chan struct{}, n)
+		} else {
+			c.rateLimiter.inFlight = nil
+		}
+	}
+}
+
+// WithLogger sets the logger used to log each request. Successful requests are logged at debug level, retries and
+// error responses at warn level and transport errors at error level. By default, nothing is logged.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithObserver adds an Observer which is notified about every request, retry and fetched page. When multiple
+// observers are added, they are notified in the order they were added.
+func WithObserver(observer Observer) ClientOption {
+	return func(c *Client) {
+		switch existing := c.observer.(type) {
+		case NoopObserver:
+			c.observer = observer
+		case multiObserver:
+			c.observer = append(existing, observer)
+		default:
+			c.observer = multiObserver{existing, observer}
+		}
+	}
+}
+
+// WithMaxPages sets the maximum number of pages fetched from a list endpoint, after which ErrPageLimitReached is
+// returned along with the items fetched so far (default = unlimited). Pagers can override it with
+// `PageOptions.MaxPages`.
+func WithMaxPages(maxPages int) ClientOption {
+	return func(c *Client) {
+		c.maxPages = maxPages
+	}
+}
@@ -1,17 +1,23 @@
 package snyk
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 )
 
+// ErrOrgNotEmpty is returned when deleting an org which still has projects without forcing the deletion
+var ErrOrgNotEmpty = errors.New("snyk: org still has projects")
+
 // Org represents a Snyk Organization
 type Org struct {
 	ID              string
 	Name            string
 	Slug            string
+	GroupID         string
 	Projects        ProjectsService
 	Targets         TargetsService
 	ContainerImages ContainerImagesService
@@ -23,53 +29,99 @@ type Org struct {
 type OrgsService service
 
 func (r *resource) intoOrg(client *Client) Org {
+	org := newOrg(client, r.ID)
+	org.Name = r.Attributes.Name
+	org.Slug = r.Attributes.Slug
+	org.GroupID = r.Attributes.GroupID
+	return org
+}
+
+// newOrg creates an Org with the given ID whose services are wired to the client
+func newOrg(client *Client, id string) Org {
 	return Org{
-		ID:   r.ID,
-		Name: r.Attributes.Name,
-		Slug: r.Attributes.Slug,
+		ID: id,
 		Projects: ProjectsService{
 			client: client,
-			orgID:  r.ID,
+			orgID:  id,
 		},
 		Targets: TargetsService{
 			client: client,
-			orgID:  r.ID,
+			orgID:  id,
 		},
 		ContainerImages: ContainerImagesService{
 			client: client,
-			orgID:  r.ID,
+			orgID:  id,
 		},
 		Issues: OrgIssuesService{
 			client: client,
-			orgID:  r.ID,
+			orgID:  id,
 		},
 		client: client,
 	}
 }
 
-// GetAll gets all available organizations
-func (s *OrgsService) GetAll() ([]Org, error) {
-	var orgs []Org
-	resources, err := getMultiResource(s.client, "/rest/orgs", nil)
-	if err != nil {
-		return nil, err
+// ListOrgsOptions filters the orgs returned by OrgsService.GetAll. Empty fields aren't filtered on.
+type ListOrgsOptions struct {
+	// Only return orgs in this group
+	GroupID string
+	// Only return orgs whose name contains this value, ignoring case
+	Name string
+	// Only return the org with this slug
+	Slug string
+}
+
+func (o ListOrgsOptions) params() url.Values {
+	params := url.Values{}
+	if o.GroupID != "" {
+		params.Set("group_id", o.GroupID)
+	}
+	if o.Name != "" {
+		params.Set("name", o.Name)
 	}
+	if o.Slug != "" {
+		params.Set("slug", o.Slug)
+	}
+	return params
+}
 
-	for _, r := range resources {
-		orgs = append(orgs, r.intoOrg(s.client))
+// GetAll gets all available organizations. If `opts` are given, only the orgs matching the first of them are
+// returned.
+func (s *OrgsService) GetAll(opts ...ListOrgsOptions) ([]Org, error) {
+	return s.GetAllWithContext(context.Background(), opts...)
+}
+
+// GetAllWithContext gets all available organizations using the provided context. If `opts` are given, only the orgs
+// matching the first of them are returned.
+func (s *OrgsService) GetAllWithContext(ctx context.Context, opts ...ListOrgsOptions) ([]Org, error) {
+	return collectPages(ctx, s.Pager(PageOptions{}, opts...))
+}
+
+// Pager returns a Pager which fetches all available organizations one page at a time. If `filters` are given, only
+// the orgs matching the first of them are returned.
+func (s *OrgsService) Pager(opts PageOptions, filters ...ListOrgsOptions) *Pager[Org] {
+	var params url.Values
+	if len(filters) > 0 {
+		params = filters[0].params()
 	}
 
-	return orgs, nil
+	return newPager(s.client, "/rest/orgs", params, opts, func(r resource) Org {
+		return r.intoOrg(s.client)
+	})
 }
 
 // Get gets the organization specified by the `identifier` parameter
 // If `identifier` is a UUID, the org is fetched by it's ID. Otherwise, it is assumed that `identifier` is an org slug
 func (s *OrgsService) Get(identifier string) (Org, error) {
+	return s.GetWithContext(context.Background(), identifier)
+}
+
+// GetWithContext gets the organization specified by the `identifier` parameter using the provided context
+func (s *OrgsService) GetWithContext(ctx context.Context, identifier string) (Org, error) {
 	var path string
 	params := url.Values{}
 	if isUUID(identifier) {
 		path = fmt.Sprintf("/rest/orgs/%s", identifier)
-		resp, err := getSingleResource(s.client, path, params)
+		resp, err := getSingleResource(ctx, s.client, path, params)
 		if err != nil {
 			return Org{}, err
 		}
@@ -79,13 +131,13 @@ func (s *OrgsService) Get(identifier string) (Org, error) {
 	path = fmt.Sprintf("/rest/orgs")
 	params.Set("slug", identifier)
 
-	multiResp, err := getMultiResource(s.client, path, params)
+	multiResp, err := getMultiResource(ctx, s.client, path, params)
 	if err != nil {
 		return Org{}, err
 	}
 
 	if len(multiResp) == 0 {
-		return Org{}, fmt.Errorf("No org found for slug '%s'", identifier)
+		return Org{}, fmt.Errorf("No org found for slug '%s'; %w", identifier, ErrNotFound)
 	}
 
 	return multiResp[0].intoOrg(s.client), nil
@@ -93,6 +145,11 @@ func (s *OrgsService) Get(identifier string) (Org, error) {
 
 // Create creates a Snyk org. sourceOrgID can optionally be passed to clone the org from another org
 func (s *OrgsService) Create(groupID, name string, sourceOrgID *string) (Org, error) {
+	return s.CreateWithContext(context.Background(), groupID, name, sourceOrgID)
+}
+
+// CreateWithContext creates a Snyk org using the provided context
+func (s *OrgsService) CreateWithContext(ctx context.Context, groupID, name string, sourceOrgID *string) (Org, error) {
 	type RequestBody struct {
 		Name        string `json:"name"`
 		GroupID     string `json:"groupId"`
@@ -100,54 +157,133 @@ func (s *OrgsService) Create(groupID, name string, sourceOrgID *string) (Org, er
 	}
 
 	body := RequestBody{
-		Name:        name,
-		GroupID:     groupID,
-		SourceOrgID: *sourceOrgID,
+		Name:    name,
+		GroupID: groupID,
+	}
+	if sourceOrgID != nil {
+		body.SourceOrgID = *sourceOrgID
 	}
 
-	resp, err := s.client.Post("/v1/org", nil, body)
+	resp, err := s.client.PostWithContext(ctx, "/v1/org", nil, body)
 	if err != nil {
-		return Org{}, fmt.Errorf("Failed to create org: %s", err.Error())
+		return Org{}, fmt.Errorf("Failed to create org; %w", err)
 	}
+	defer resp.Body.Close()
 
 	type NewOrg struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-		Slug string `json:"slug"`
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Slug  string `json:"slug"`
+		Group *struct {
+			ID string `json:"id"`
+		} `json:"group"`
 	}
 
 	respBody := NewOrg{}
 	err = json.NewDecoder(resp.Body).Decode(&respBody)
 	if err != nil {
-		return Org{}, err
+		return Org{}, fmt.Errorf("Failed to create org; %w", err)
 	}
 
-	newOrg := Org{
-		ID:   respBody.ID,
-		Name: respBody.Name,
-		Slug: respBody.Slug,
-		Projects: ProjectsService{
-			client: s.client,
-			orgID:  respBody.ID,
-		},
-		Targets: TargetsService{
-			client: s.client,
-			orgID:  respBody.ID,
+	org := newOrg(s.client, respBody.ID)
+	org.Name = respBody.Name
+	org.Slug = respBody.Slug
+	org.GroupID = groupID
+	if respBody.Group != nil {
+		org.GroupID = respBody.Group.ID
+	}
+
+	return org, nil
+}
+
+// Update renames the org. The org is refreshed from the updated org returned by Snyk.
+func (o *Org) Update(name string) error {
+	return o.UpdateWithContext(context.Background(), name)
+}
+
+// UpdateWithContext renames the org using the provided context. The org is refreshed from the updated org returned
+// by Snyk.
+func (o *Org) UpdateWithContext(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/rest/orgs/%s", o.ID)
+	params := url.Values{}
+	params.Set("version", o.client.APIVersion)
+
+	body := map[string]any{
+		"data": map[string]any{
+			"type":       "org",
+			"id":         o.ID,
+			"attributes": map[string]string{"name": name},
 		},
-		client: s.client,
 	}
 
-	return newOrg, nil
+	resp, err := o.client.PatchWithContext(ctx, path, params, body)
+	if err != nil {
+		return fmt.Errorf("Failed to update org; %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody := singleResourceResp{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return fmt.Errorf("Failed to update org; %w", err)
+	}
+
+	updated := respBody.Data.intoOrg(o.client)
+	o.Name = updated.Name
+	if updated.Slug != "" {
+		o.Slug = updated.Slug
+	}
+	return nil
+}
+
+// DeleteOrgOptions sets how Org.Delete deletes an org
+type DeleteOrgOptions struct {
+	// Delete the org even if it still has projects, deleting the projects with it
+	Force bool
+}
+
+// Delete permanently deletes the org. Unless `opts` force the deletion, the org's projects are checked first and
+// ErrOrgNotEmpty is returned if it has any.
+func (o *Org) Delete(ctx context.Context, opts ...DeleteOrgOptions) error {
+	force := len(opts) > 0 && opts[0].Force
+	if !force {
+		projects, err := o.Projects.Pager(PageOptions{Limit: 1}).Next(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to delete org; %w", err)
+		}
+		if len(projects) > 0 {
+			return fmt.Errorf("Failed to delete org %s; %w", o.ID, ErrOrgNotEmpty)
+		}
+	}
+
+	urlPath := fmt.Sprintf("/v1/org/%s", o.ID)
+	resp, err := o.client.DeleteWithContext(ctx, urlPath, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete org; %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
 }
 
-// UpdateUserRole sets the given `user`'s role to the given `roleId`
+// UpdateUserRole sets the given `user`'s role to the given `roleId`. Use Group.UpdateUserRoleByName to set a role by
+// its name instead.
 func (o *Org) UpdateUserRole(user User, roleID string) error {
+	return o.UpdateUserRoleWithContext(context.Background(), user, roleID)
+}
+
+// UpdateUserRoleWithContext sets the given `user`'s role to the given `roleId` using the provided context
+func (o *Org) UpdateUserRoleWithContext(ctx context.Context, user User, roleID string) error {
+	if roleID == "" {
+		return fmt.Errorf("Failed to update user role; %w: the role ID is empty", ErrInvalidRole)
+	}
+
 	urlPath := fmt.Sprintf("/v1/org/%s/members/update/%s", o.ID, user.ID)
 	body := map[string]string{"rolePublicId": roleID}
 
-	_, err := o.client.Put(urlPath, body)
+	_, err := o.client.PutWithContext(ctx, urlPath, body)
 	if err != nil {
-		return fmt.Errorf("Failed to update user role; %s", err.Error())
+		return fmt.Errorf("Failed to update user role; %w", err)
 	}
 
 	return nil
@@ -163,15 +299,20 @@ type OrgSettings struct {
 
 // GetSettings returns the currently configured settings for the given org
 func (o *Org) GetSettings() (OrgSettings, error) {
+	return o.GetSettingsWithContext(context.Background())
+}
+
+// GetSettingsWithContext returns the currently configured settings for the given org using the provided context
+func (o *Org) GetSettingsWithContext(ctx context.Context) (OrgSettings, error) {
 	urlPath := fmt.Sprintf("/v1/org/%s/settings", o.ID)
 	settings := OrgSettings{}
-	resp, err := o.client.Get(urlPath, nil)
+	resp, err := o.client.GetWithContext(ctx, urlPath, nil)
 	if err != nil {
-		return settings, fmt.Errorf("Failed to get org settings; %s", err.Error())
+		return settings, fmt.Errorf("Failed to get org settings; %w", err)
 	}
 	err = json.NewDecoder(resp.Body).Decode(&settings)
 	if err != nil {
-		return settings, fmt.Errorf("Failed to get org settings; %s", err.Error())
+		return settings, fmt.Errorf("Failed to get org settings; %w", err)
 	}
 
 	return settings, nil
@@ -179,11 +320,16 @@ func (o *Org) GetSettings() (OrgSettings, error) {
 
 // UpdateSettings updates the provided settings on the given org
 func (o *Org) UpdateSettings(settings OrgSettings) error {
+	return o.UpdateSettingsWithContext(context.Background(), settings)
+}
+
+// UpdateSettingsWithContext updates the provided settings on the given org using the provided context
+func (o *Org) UpdateSettingsWithContext(ctx context.Context, settings OrgSettings) error {
 	urlPath := fmt.Sprintf("/v1/org/%s/settings", o.ID)
 
-	_, err := o.client.Put(urlPath, settings)
+	_, err := o.client.PutWithContext(ctx, urlPath, settings)
 	if err != nil {
-		return fmt.Errorf("Failed to update org settings; %s", err.Error())
+		return fmt.Errorf("Failed to update org settings; %w", err)
 	}
 
 	return nil
@@ -192,18 +338,23 @@ func (o *Org) UpdateSettings(settings OrgSettings) error {
 // GetIntegrations returns a map of all configured integrations for the org.
 // The integration name is the key and the integration ID is the value.
 func (o *Org) GetIntegrations() (map[string]string, error) {
+	return o.GetIntegrationsWithContext(context.Background())
+}
+
+// GetIntegrationsWithContext returns a map of all configured integrations for the org using the provided context
+func (o *Org) GetIntegrationsWithContext(ctx context.Context) (map[string]string, error) {
 	urlPath := fmt.Sprintf("/v1/org/%s/integrations", o.ID)
 
-	resp, err := o.client.Get(urlPath, nil)
+	resp, err := o.client.GetWithContext(ctx, urlPath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get org integrations; %s", err.Error())
+		return nil, fmt.Errorf("Failed to get org integrations; %w", err)
 	}
 
 	integrations := make(map[string]string)
 
 	err = json.NewDecoder(resp.Body).Decode(&integrations)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get org integrations; %s", err.Error())
+		return nil, fmt.Errorf("Failed to get org integrations; %w", err)
 	}
 
 	return integrations, nil
@@ -211,18 +362,27 @@ func (o *Org) GetIntegrations() (map[string]string, error) {
 
 // CloneIntegration clones an integration from the org to the given `destinationOrgID`
 func (o *Org) CloneIntegration(integrationID, destinationOrgID string) (string, error) {
+	return o.CloneIntegrationWithContext(context.Background(), integrationID, destinationOrgID)
+}
+
+// CloneIntegrationWithContext clones an integration from the org to the given `destinationOrgID` using the provided
+// context
+func (o *Org) CloneIntegrationWithContext(ctx context.Context, integrationID, destinationOrgID string) (string, error) {
 	urlPath := fmt.Sprintf("/v1/org/%s/integrations/%s/clone", o.ID, integrationID)
 
 	body := make(map[string]string)
 	body["destinationOrgPublicId"] = destinationOrgID
 
-	resp, err := o.client.Post(urlPath, nil, body)
+	resp, err := o.client.PostWithContext(ctx, urlPath, nil, body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to clone integration; %w", err)
+	}
 
 	respBody := make(map[string]string)
 
 	err = json.NewDecoder(resp.Body).Decode(&respBody)
 	if err != nil {
-		return "", fmt.Errorf("Failed to clone integration; %s", err.Error())
+		return "", fmt.Errorf("Failed to clone integration; %w", err)
 	}
 
 	return respBody["newIntegrationId"], nil
@@ -250,16 +410,28 @@ type ImportTarget struct {
 	ExclusionGlobs string `json:"exclusionGlobs,omitempty"`
 }
 
-// ImportProject imports the provided target to Snyk using the given `integrationID`
-func (o *Org) ImportProject(integrationID string, importTarget ImportTarget) error {
+// ImportProject imports the provided target to Snyk using the given `integrationID`. Imports run asynchronously, so
+// the returned ImportJob can be used to wait for the import to finish.
+func (o *Org) ImportProject(integrationID string, importTarget ImportTarget) (ImportJob, error) {
+	return o.ImportProjectWithContext(context.Background(), integrationID, importTarget)
+}
+
+// ImportProjectWithContext imports the provided target to Snyk using the given `integrationID` and the provided context
+func (o *Org) ImportProjectWithContext(ctx context.Context, integrationID string, importTarget ImportTarget) (ImportJob, error) {
 	urlPath := fmt.Sprintf("/v1/org/%s/integrations/%s/import", o.ID, integrationID)
 
-	_, err := o.client.Post(urlPath, nil, importTarget)
+	resp, err := o.client.PostWithContext(ctx, urlPath, nil, importTarget)
 	if err != nil {
-		return fmt.Errorf("Failed to import target; %s", err.Error())
+		return ImportJob{}, fmt.Errorf("Failed to import target; %w", err)
 	}
+	resp.Body.Close()
 
-	return nil
+	job, err := newImportJob(o.client, o.ID, resp.Header.Get("Location"))
+	if err != nil {
+		return ImportJob{}, fmt.Errorf("Failed to import target; %w", err)
+	}
+
+	return job, nil
 }
 
 func isUUID(value string) bool {
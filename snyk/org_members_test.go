@@ -0,0 +1,275 @@
+package snyk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const (
+	testUserID       = "b3a4d1c2-5f1e-4c6a-9d8e-7f6a5b4c3d2e"
+	testOrgAdminRole = "5d6e7f80-9a1b-4c2d-8e3f-4a5b6c7d8e9f"
+)
+
+// mockOrgExists mocks getting the org, which is how a missing REST endpoint is told apart from a missing org
+func mockOrgExists() {
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s", testOrgID)).
+		Reply(200).
+		JSON(map[string]any{
+			"data": map[string]any{"type": "org", "id": testOrgID, "attributes": map[string]any{"name": "org1"}},
+		})
+}
+
+// mockOrgMemberships mocks listing the memberships of the org, filtered by `userID` if it isn't empty
+func mockOrgMemberships(userID string) {
+	req := gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/memberships", testOrgID)).
+		MatchParam("version", membershipsAPIVersion)
+	if userID != "" {
+		req.MatchParam("user_id", userID)
+	}
+	req.Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{
+					"type": "org_membership",
+					"id":   "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b",
+					"relationships": map[string]any{
+						"user": map[string]any{
+							"data": map[string]any{
+								"type": "user",
+								"id":   testUserID,
+								"attributes": map[string]any{
+									"name":     "Jo Bloggs",
+									"username": "jbloggs",
+									"email":    "jo@example.com",
+								},
+							},
+						},
+						"role": map[string]any{
+							"data": map[string]any{
+								"type":       "org_role",
+								"id":         testOrgAdminRole,
+								"attributes": map[string]any{"name": "Org Admin"},
+							},
+						},
+					},
+				},
+			},
+		})
+}
+
+func TestOrgMembers(t *testing.T) {
+	defer gock.Off()
+
+	mockOrgMemberships("")
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	members, err := org.Members()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []OrgMember{{
+		MembershipID: "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b",
+		UserID:       testUserID,
+		Name:         "Jo Bloggs",
+		Username:     "jbloggs",
+		Email:        "jo@example.com",
+		Role:         "Org Admin",
+		RoleID:       testOrgAdminRole,
+	}}, members)
+}
+
+func TestOrgMembersFallsBackToV1(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/memberships", testOrgID)).
+		Reply(404)
+	mockOrgExists()
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/org/%s/members", testOrgID)).
+		MatchParam("includeGroupAdmins", "true").
+		Reply(200).
+		JSON([]map[string]string{
+			{"id": testUserID, "name": "Jo Bloggs", "username": "jbloggs", "email": "jo@example.com", "role": "admin"},
+		})
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	members, err := org.Members()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []OrgMember{{
+		UserID:   testUserID,
+		Name:     "Jo Bloggs",
+		Username: "jbloggs",
+		Email:    "jo@example.com",
+		Role:     "Org Admin",
+	}}, members)
+}
+
+func TestOrgInvite(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/rest/orgs/%s/invites", testOrgID)).
+		MatchParam("version", membershipsAPIVersion).
+		MatchType("application/vnd.api+json").
+		JSON(map[string]any{
+			"data": map[string]any{
+				"type":       "org_invitation",
+				"attributes": map[string]string{"email": "new@example.com", "role": testOrgAdminRole},
+			},
+		}).
+		Reply(201).
+		JSON(map[string]any{
+			"data": map[string]any{
+				"type": "org_invitation",
+				"id":   "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+				"attributes": map[string]any{
+					"email":     "new@example.com",
+					"role":      testOrgAdminRole,
+					"is_active": true,
+				},
+			},
+		})
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	invite, err := org.Invite("new@example.com", testOrgAdminRole)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, OrgInvite{
+		ID:       "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+		Email:    "new@example.com",
+		RoleID:   testOrgAdminRole,
+		IsActive: true,
+	}, invite)
+}
+
+func TestOrgInviteFallsBackToV1(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/rest/orgs/%s/invites", testOrgID)).
+		Reply(404)
+	mockOrgExists()
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/org/%s/invite", testOrgID)).
+		JSON(map[string]string{"email": "new@example.com", "role": testOrgAdminRole}).
+		Reply(200)
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	invite, err := org.Invite("new@example.com", testOrgAdminRole)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, "new@example.com", invite.Email)
+	assert.Equal(t, "", invite.ID)
+}
+
+func TestOrgInvitesAndRevoke(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/invites", testOrgID)).
+		MatchParam("version", membershipsAPIVersion).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{
+					"type":       "org_invitation",
+					"id":         "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+					"attributes": map[string]any{"email": "new@example.com", "role": testOrgAdminRole, "is_active": true},
+				},
+			},
+		})
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/rest/orgs/%s/invites/0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", testOrgID)).
+		MatchParam("version", membershipsAPIVersion).
+		Reply(204)
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	invites, err := org.Invites()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(invites))
+	assert.Equal(t, "new@example.com", invites[0].Email)
+
+	err = org.RevokeInvite(invites[0].ID)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgRemoveMember(t *testing.T) {
+	defer gock.Off()
+
+	mockOrgMemberships(testUserID)
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/rest/orgs/%s/memberships/e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b", testOrgID)).
+		MatchParam("version", membershipsAPIVersion).
+		Reply(204)
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	err := org.RemoveMember(testUserID)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgRemoveMemberFallsBackToV1(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/memberships", testOrgID)).
+		Reply(404)
+	mockOrgExists()
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/v1/org/%s/members/%s", testOrgID, testUserID)).
+		Reply(200)
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	err := org.RemoveMember(testUserID)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgRemoveMemberNotAMember(t *testing.T) {
+	defer gock.Off()
+
+	mockOrgMemberships("not-a-member")
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	err := org.RemoveMember("not-a-member")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgMembersUnknownOrg(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/memberships", testOrgID)).
+		Times(2).
+		Reply(404)
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s", testOrgID)).
+		Times(2).
+		Reply(404)
+
+	org := newOrg(NewClient("mock-token"), testOrgID)
+
+	// The v1 API isn't tried when the org itself doesn't exist
+	_, err := org.Members()
+	assert.True(t, errors.Is(err, ErrNotFound))
+	err = org.RemoveMember(testUserID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, gock.HasUnmatchedRequest())
+}
@@ -1,6 +1,8 @@
 package snyk
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -9,7 +11,8 @@ import (
 )
 
 const (
-	testOrgID = "8bcff720-99a4-4442-bb35-31f7a74d27b0"
+	testOrgID   = "8bcff720-99a4-4442-bb35-31f7a74d27b0"
+	testGroupID = "a060a49f-636e-480f-9e14-38e773b2a97f"
 )
 
 func TestOrgGet(t *testing.T) {
@@ -18,7 +21,7 @@ func TestOrgGet(t *testing.T) {
 	respJSON, err := loadFixture("fixtures/org_get.json")
 	assert.NoError(t, err)
 
-	gock.New(baseURL).
+	gock.New(defaultBaseURL).
 		Get(fmt.Sprintf("/rest/orgs/%s", testOrgID)).
 		MatchParam("version", defaultAPIVersion).
 		Reply(200).
@@ -31,3 +34,143 @@ func TestOrgGet(t *testing.T) {
 	assert.Equal(t, "org1-abc", org.Slug)
 	assert.Equal(t, testOrgID, org.ID)
 }
+
+func TestOrgsGetAllWithFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		MatchParam("version", defaultAPIVersion).
+		MatchParam("group_id", testGroupID).
+		MatchParam("name", "^platform$").
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{
+					"type":       "org",
+					"id":         testOrgID,
+					"attributes": map[string]any{"name": "Platform", "slug": "platform", "group_id": testGroupID},
+				},
+			},
+		})
+
+	client := NewClient("mock-token")
+	orgs, err := client.Orgs.GetAll(ListOrgsOptions{GroupID: testGroupID, Name: "platform"})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 1, len(orgs))
+	assert.Equal(t, testGroupID, orgs[0].GroupID)
+	assert.Equal(t, testOrgID, orgs[0].Projects.orgID)
+}
+
+func TestOrgsCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Post("/v1/org").
+		JSON(map[string]string{"name": "new-org", "groupId": testGroupID}).
+		Reply(201).
+		JSON(map[string]any{
+			"id":    testOrgID,
+			"name":  "new-org",
+			"slug":  "new-org",
+			"group": map[string]string{"id": testGroupID, "name": "group1"},
+		})
+
+	client := NewClient("mock-token")
+	org, err := client.Orgs.Create(testGroupID, "new-org", nil)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, testOrgID, org.ID)
+	assert.Equal(t, "new-org", org.Slug)
+	assert.Equal(t, testGroupID, org.GroupID)
+	assert.Equal(t, testOrgID, org.ContainerImages.orgID)
+	assert.Equal(t, testOrgID, org.Issues.orgID)
+}
+
+func TestOrgUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Patch(fmt.Sprintf("/rest/orgs/%s", testOrgID)).
+		MatchParam("version", defaultAPIVersion).
+		MatchType("application/vnd.api+json").
+		JSON(map[string]any{
+			"data": map[string]any{"type": "org", "id": testOrgID, "attributes": map[string]string{"name": "renamed"}},
+		}).
+		Reply(200).
+		JSON(map[string]any{
+			"data": map[string]any{
+				"type":       "org",
+				"id":         testOrgID,
+				"attributes": map[string]any{"name": "renamed", "slug": "org1-abc"},
+			},
+		})
+
+	client := NewClient("mock-token")
+	org := newOrg(client, testOrgID)
+	org.Name = "org1"
+
+	err := org.Update("renamed")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, "renamed", org.Name)
+	assert.Equal(t, "org1-abc", org.Slug)
+}
+
+func mockOrgProjects(count int) {
+	projects := []map[string]any{}
+	for i := 0; i < count; i++ {
+		projects = append(projects, map[string]any{"type": "project", "id": testProjectID})
+	}
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects", testOrgID)).
+		MatchParam("limit", "1").
+		Reply(200).
+		JSON(map[string]any{"data": projects})
+}
+
+func TestOrgDeleteRefusesOrgWithProjects(t *testing.T) {
+	defer gock.Off()
+
+	mockOrgProjects(1)
+
+	client := NewClient("mock-token")
+	org := newOrg(client, testOrgID)
+
+	err := org.Delete(context.Background())
+	assert.True(t, errors.Is(err, ErrOrgNotEmpty))
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgDelete(t *testing.T) {
+	defer gock.Off()
+
+	mockOrgProjects(0)
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/v1/org/%s", testOrgID)).
+		Reply(204)
+
+	client := NewClient("mock-token")
+	org := newOrg(client, testOrgID)
+
+	err := org.Delete(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgDeleteForced(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/v1/org/%s", testOrgID)).
+		Reply(204)
+
+	client := NewClient("mock-token")
+	org := newOrg(client, testOrgID)
+
+	err := org.Delete(context.Background(), DeleteOrgOptions{Force: true})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,257 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultPageSize = 100
+
+var (
+	// ErrNoMorePages is returned by Pager.Next when every page has already been fetched
+	ErrNoMorePages = errors.New("snyk: no more pages")
+	// ErrPaginationLoop is returned when the Snyk API returns a "next" link which has already been followed. A crawl
+	// which stopped with it can't be resumed, since its cursor would only lead back to pages already fetched.
+	ErrPaginationLoop = errors.New("snyk: pagination loop detected")
+	// ErrPageLimitReached is returned when there are more pages to fetch than the maximum page count allows
+	ErrPageLimitReached = errors.New("snyk: maximum page count reached")
+)
+
+// PageOptions controls how a Pager fetches pages
+type PageOptions struct {
+	// The number of items requested per page (default = 100)
+	Limit int
+	// The cursor to start fetching from. Pass the value of Pager.Cursor from an earlier crawl to resume it.
+	StartingAfter string
+	// The maximum number of pages to fetch. Once reached, fetching another page returns ErrPageLimitReached. Defaults
+	// to the client's limit set by WithMaxPages, which is unlimited by default.
+	MaxPages int
+}
+
+// Pager fetches the items of a list endpoint one page at a time, so that only a single page is held in memory and
+// fetching can be stopped early.
+//
+//	pager := org.Projects.Pager(snyk.PageOptions{})
+//	for pager.More() {
+//		projects, err := pager.Next(ctx)
+//		...
+//	}
+type Pager[T any] struct {
+	pages   *resourcePager
+	convert func(resource) T
+}
+
+// More returns whether there are pages left to fetch
+func (p *Pager[T]) More() bool {
+	return !p.pages.done
+}
+
+// Next fetches the next page of items. ErrNoMorePages is returned once every page has been fetched.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	resources, err := p.pages.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]T, 0, len(resources))
+	for _, r := range resources {
+		items = append(items, p.convert(r))
+	}
+
+	return items, nil
+}
+
+// Cursor returns the `starting_after` cursor of the next page to be fetched. It is empty before the first page has
+// been fetched, unless the pager was started from a cursor, once every page has been fetched, and once a pagination
+// loop has been detected.
+func (p *Pager[T]) Cursor() string {
+	return p.pages.cursor
+}
+
+// Page returns the number of pages fetched so far
+func (p *Pager[T]) Page() int {
+	return p.pages.page
+}
+
+func newPager[T any](client *Client, path string, params url.Values, opts PageOptions, convert func(resource) T) *Pager[T] {
+	return &Pager[T]{
+		pages:   newResourcePager(client, path, params, opts),
+		convert: convert,
+	}
+}
+
+// resourcePager fetches the pages of a REST list endpoint by following the `links.next` URL of each page
+type resourcePager struct {
+	client     *Client
+	urlPath    string
+	params     url.Values
+	apiVersion string
+	cursor     string
+	page       int
+	maxPages   int
+	done       bool
+	// An error which prevents the next page from being fetched
+	err error
+	// The cursors and "next" links which have already been followed, used to detect pagination loops
+	seen map[string]bool
+}
+
+func newResourcePager(client *Client, path string, addlParams url.Values, opts PageOptions) *resourcePager {
+	params := url.Values{}
+	if !addlParams.Has("version") {
+		params.Set("version", client.APIVersion)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	params.Set("limit", strconv.Itoa(limit))
+
+	if addlParams != nil {
+		for k, vSlice := range addlParams {
+			for _, v := range vSlice {
+				params.Set(k, v)
+			}
+		}
+	}
+
+	if opts.StartingAfter != "" {
+		params.Set("starting_after", opts.StartingAfter)
+	}
+
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = client.maxPages
+	}
+
+	p := &resourcePager{
+		client:     client,
+		urlPath:    path,
+		params:     params,
+		apiVersion: params.Get("version"),
+		cursor:     opts.StartingAfter,
+		maxPages:   maxPages,
+		seen:       map[string]bool{},
+	}
+	if opts.StartingAfter != "" {
+		p.seen[opts.StartingAfter] = true
+	}
+
+	return p
+}
+
+// next fetches the next page of resources
+func (p *resourcePager) next(ctx context.Context) ([]resource, error) {
+	if p.done {
+		return nil, ErrNoMorePages
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.maxPages > 0 && p.page >= p.maxPages {
+		return nil, ErrPageLimitReached
+	}
+
+	resp, err := p.client.GetWithContext(ctx, p.urlPath, p.params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody := multiResourceResp{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return nil, err
+	}
+
+	p.page++
+	pagePath := "/" + strings.TrimPrefix(strings.SplitN(p.urlPath, "?", 2)[0], "/")
+	p.client.observer.PageFetched(ctx, PageInfo{
+		Path:         pagePath,
+		PathTemplate: PathTemplate(pagePath),
+		Page:         p.page,
+		Items:        len(respBody.Data),
+	})
+
+	if respBody.Links.Next == "" || len(respBody.Data) == 0 {
+		p.done = true
+		p.cursor = ""
+		return respBody.Data, nil
+	}
+
+	// The page was fetched successfully, so it is returned even if the "next" link can't be followed. The error is
+	// returned when fetching the next page instead.
+	p.err = p.setNext(respBody.Links.Next)
+
+	return respBody.Data, nil
+}
+
+// setNext prepares the pager to fetch the page at the given "next" link
+func (p *resourcePager) setNext(next string) error {
+	// The "next" link may be an absolute URL. Only its path and query are used so that the following page is
+	// requested from the client's base URL
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return err
+	}
+	urlPath := nextURL.RequestURI()
+
+	// Some versions of the Snyk REST API have "/rest" at the beginning of the "next" URL path... some don't...
+	if !strings.HasPrefix(urlPath, "/rest") {
+		urlPath = fmt.Sprintf("/rest/%s", strings.TrimPrefix(urlPath, "/"))
+	}
+
+	// Prefer the cursor for detecting loops since the rest of the link can change between pages while still pointing
+	// at the same page
+	cursor := nextURL.Query().Get("starting_after")
+	seenKey := cursor
+	if seenKey == "" {
+		seenKey = urlPath
+	}
+	if p.seen[seenKey] {
+		// The cursor of the page just fetched would fetch it again if used to resume, so it is cleared
+		p.cursor = ""
+		return ErrPaginationLoop
+	}
+	p.seen[seenKey] = true
+
+	p.urlPath = urlPath
+	p.params = nil
+	p.cursor = cursor
+
+	// Some versions of the Snyk REST API include the 'version' param from the original request in the "next" URL path... some don't...
+	if nextURL.Query().Get("version") == "" {
+		p.params = nextURL.Query()
+		p.params.Set("version", p.apiVersion)
+	}
+
+	return nil
+}
+
+// collectPages fetches every remaining page of the pager. If fetching fails after at least one page was fetched, the
+// items fetched so far are returned along with a `*PartialResultError`.
+func collectPages[T any](ctx context.Context, pager *Pager[T]) ([]T, error) {
+	var items []T
+	pages := 0
+	for pager.More() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			if pages == 0 {
+				return nil, err
+			}
+			return items, &PartialResultError{Err: err, Pages: pages, Cursor: pager.Cursor()}
+		}
+		pages++
+		items = append(items, page...)
+	}
+	return items, nil
+}
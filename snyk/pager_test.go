@@ -0,0 +1,187 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const testContainerImageCursor = "v2.mXj4PTbNK6N_N7K3lcYwjZvuYLBeeSCSNfK9EznyHAo="
+
+func TestPagerStopsEarlyAndResumes(t *testing.T) {
+	defer gock.Off()
+
+	respJSONp1, err := loadFixture("fixtures/container_image_get_all_page1.json")
+	assert.NoError(t, err)
+
+	respJSONp2, err := loadFixture("fixtures/container_image_get_all_page2.json")
+	assert.NoError(t, err)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		MatchParam("limit", "10").
+		Reply(200).
+		JSON(respJSONp1)
+
+	client := NewClient("mock-token")
+	images := ContainerImagesService{client: client, orgID: testOrgID}
+	ctx := context.Background()
+
+	pager := images.Pager(PageOptions{Limit: 10})
+	assert.True(t, pager.More())
+	page, err := pager.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(page))
+	assert.True(t, pager.More())
+	assert.Equal(t, 1, pager.Page())
+	assert.Equal(t, testContainerImageCursor, pager.Cursor())
+	assert.True(t, gock.IsDone())
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		MatchParam("starting_after", testContainerImageCursor).
+		Reply(200).
+		JSON(respJSONp2)
+
+	resumed := images.Pager(PageOptions{Limit: 10, StartingAfter: pager.Cursor()})
+	page, err = resumed.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(page))
+	assert.False(t, resumed.More())
+	assert.Equal(t, "", resumed.Cursor())
+
+	_, err = resumed.Next(ctx)
+	assert.IsError(t, err, ErrNoMorePages)
+}
+
+func TestPagerDetectsLoops(t *testing.T) {
+	defer gock.Off()
+
+	page := map[string]any{
+		"data":  []map[string]any{{"id": "group-1", "type": "group", "attributes": map[string]any{"name": "Group1"}}},
+		"links": map[string]any{"next": "/groups?starting_after=abc"},
+	}
+
+	gock.New(defaultBaseURL).
+		Get("/rest/groups").
+		Times(2).
+		Reply(200).
+		JSON(page)
+
+	client := NewClient("mock-token")
+	groups, err := client.Groups.GetAll()
+
+	var partialErr *PartialResultError
+	assert.True(t, errors.As(err, &partialErr))
+	assert.IsError(t, err, ErrPaginationLoop)
+	assert.Equal(t, 2, partialErr.Pages)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, "", partialErr.Cursor)
+}
+
+func TestPagerResumesAfterLoop(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/groups").
+		Reply(200).
+		JSON(map[string]any{
+			"data":  []map[string]any{{"id": "group-1", "type": "group", "attributes": map[string]any{"name": "Group1"}}},
+			"links": map[string]any{"next": "/groups?starting_after=abc"},
+		})
+	gock.New(defaultBaseURL).
+		Get("/rest/groups").
+		MatchParam("starting_after", "abc").
+		Reply(200).
+		JSON(map[string]any{
+			"data":  []map[string]any{{"id": "group-2", "type": "group", "attributes": map[string]any{"name": "Group2"}}},
+			"links": map[string]any{"next": "/groups?starting_after=abc"},
+		})
+
+	client := NewClient("mock-token")
+	ctx := context.Background()
+
+	pager := client.Groups.Pager(PageOptions{})
+	groups, err := collectPages(ctx, pager)
+	assert.IsError(t, err, ErrPaginationLoop)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, "", pager.Cursor())
+
+	// Resuming from the cursor of the failed crawl starts again from the first page rather than repeating the
+	// last page collected
+	var partialErr *PartialResultError
+	assert.True(t, errors.As(err, &partialErr))
+	gock.New(defaultBaseURL).
+		Get("/rest/groups").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return !req.URL.Query().Has("starting_after"), nil
+		}).
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{{"id": "group-1", "type": "group", "attributes": map[string]any{"name": "Group1"}}},
+		})
+
+	groups, err = collectPages(ctx, client.Groups.Pager(PageOptions{StartingAfter: partialErr.Cursor}))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(groups))
+	assert.Equal(t, "group-1", groups[0].ID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestPaginationPartialResults(t *testing.T) {
+	defer gock.Off()
+
+	respJSONp1, err := loadFixture("fixtures/container_image_get_all_page1.json")
+	assert.NoError(t, err)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		Reply(200).
+		JSON(respJSONp1)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("starting_after", testContainerImageCursor).
+		Reply(404)
+
+	client := NewClient("mock-token")
+	images := ContainerImagesService{client: client, orgID: testOrgID}
+
+	result, err := images.GetAll()
+
+	var partialErr *PartialResultError
+	assert.True(t, errors.As(err, &partialErr))
+	assert.IsError(t, err, ErrNotFound)
+	assert.Equal(t, 1, partialErr.Pages)
+	assert.Equal(t, testContainerImageCursor, partialErr.Cursor)
+	assert.Equal(t, 10, len(result))
+}
+
+func TestPaginationMaxPages(t *testing.T) {
+	defer gock.Off()
+
+	respJSONp1, err := loadFixture("fixtures/container_image_get_all_page1.json")
+	assert.NoError(t, err)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/container_images", testOrgID)).
+		MatchParam("version", "2024-01-23~beta").
+		Reply(200).
+		JSON(respJSONp1)
+
+	client := NewClient("mock-token", WithMaxPages(1))
+	images := ContainerImagesService{client: client, orgID: testOrgID}
+
+	result, err := images.GetAll()
+	assert.IsError(t, err, ErrPageLimitReached)
+	assert.Equal(t, 10, len(result))
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,29 @@
+package snyk
+
+import (
+	"context"
+	"time"
+)
+
+// backoff spaces out the polling of an asynchronous job, doubling the delay after each poll up to a maximum
+type backoff struct {
+	clock    Clock
+	delay    time.Duration
+	maxDelay time.Duration
+}
+
+func newBackoff(clock Clock, initialDelay, maxDelay time.Duration) *backoff {
+	return &backoff{clock: clock, delay: initialDelay, maxDelay: maxDelay}
+}
+
+// wait sleeps until the job should be polled again, or returns the context's error if it is done first
+func (b *backoff) wait(ctx context.Context) error {
+	if err := b.clock.Sleep(ctx, b.delay); err != nil {
+		return err
+	}
+	b.delay *= 2
+	if b.delay > b.maxDelay {
+		b.delay = b.maxDelay
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package snyk
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -19,13 +22,88 @@ type Project struct {
 	BusinessCriticality []string
 	Environment         []string
 	Lifecycle           []string
-	Tags                []tag
+	Tags                []Tag
 	ReadOnly            bool
 	Meta                meta
 	Relationships       map[string]Relationship
-	Issues              ProjectIssuesService
-	orgID               string
-	client              *Client
+	// The project's target, only set when listing projects with `ListProjectsOptions.ExpandTarget`
+	Target *ProjectTarget
+	Issues ProjectIssuesService
+	orgID  string
+	client *Client
+}
+
+// ProjectTarget is the target of a Project, as included by `ListProjectsOptions.ExpandTarget`
+type ProjectTarget struct {
+	ID          string
+	DisplayName string
+	URL         string
+}
+
+// ListProjectsOptions filters the projects returned by ProjectsService.GetAll. Filters which are left empty are not
+// applied. Filters which take several values match projects with any of the values.
+type ListProjectsOptions struct {
+	// Only return projects in these targets
+	TargetIDs []string
+	// Only return projects with this target reference, e.g. a branch name
+	TargetReference string
+	// Only return projects for this target file, e.g. `package.json`
+	TargetFile string
+	// Only return projects with these IDs
+	IDs []string
+	// Only return projects with these exact names
+	Names []string
+	// Only return projects whose names start with any of these prefixes
+	NamesStartWith []string
+	// Only return projects from these origins, e.g. `github` or `cli`
+	Origins []string
+	// Only return projects of these types, e.g. `npm` or `maven`
+	Types []string
+	// Only return projects with all of these tags
+	Tags []Tag
+	// Only return projects with these business criticality attributes, e.g. `critical` or `high`
+	BusinessCriticality []string
+	// Only return projects with these environment attributes, e.g. `frontend` or `external`
+	Environment []string
+	// Only return projects with these lifecycle attributes, e.g. `production` or `development`
+	Lifecycle []string
+	// Include the display name and URL of each project's target in `Project.Target`
+	ExpandTarget bool
+}
+
+func (o ListProjectsOptions) params() url.Values {
+	params := url.Values{}
+	setListParam(params, "target_id", o.TargetIDs)
+	if o.TargetReference != "" {
+		params.Set("target_reference", o.TargetReference)
+	}
+	if o.TargetFile != "" {
+		params.Set("target_file", o.TargetFile)
+	}
+	setListParam(params, "ids", o.IDs)
+	setListParam(params, "names", o.Names)
+	setListParam(params, "names_start_with", o.NamesStartWith)
+	setListParam(params, "origins", o.Origins)
+	setListParam(params, "types", o.Types)
+	tags := []string{}
+	for _, t := range o.Tags {
+		tags = append(tags, t.Key+":"+t.Value)
+	}
+	setListParam(params, "tags", tags)
+	setListParam(params, "business_criticality", o.BusinessCriticality)
+	setListParam(params, "environment", o.Environment)
+	setListParam(params, "lifecycle", o.Lifecycle)
+	if o.ExpandTarget {
+		params.Set("expand", "target")
+	}
+	return params
+}
+
+// setListParam sets an array query parameter in the comma separated format used by the REST API
+func setListParam(params url.Values, key string, values []string) {
+	if len(values) > 0 {
+		params.Set(key, strings.Join(values, ","))
+	}
 }
 
 // ProjectsService handles requests for Project resources on the given Org
@@ -51,6 +129,7 @@ func (r *resource) intoProject(client *Client, orgID string) Project {
 		ReadOnly:            r.Attributes.ReadOnly,
 		Meta:                r.Meta,
 		Relationships:       r.Relationships,
+		Target:              r.intoProjectTarget(),
 		Issues: ProjectIssuesService{
 			client:        client,
 			projectID:     r.ID,
@@ -62,30 +141,56 @@ func (r *resource) intoProject(client *Client, orgID string) Project {
 	}
 }
 
-// GetAll gets all projects for the org
-func (s *ProjectsService) GetAll() ([]Project, error) {
-	var projects []Project
+// intoProjectTarget returns the project's target if it was expanded in the response
+func (r *resource) intoProjectTarget() *ProjectTarget {
+	target, ok := r.Relationships["target"]
+	if !ok || target.Data.Attributes == nil {
+		return nil
+	}
+
+	projectTarget := &ProjectTarget{ID: target.Data.ID}
+	projectTarget.DisplayName, _ = target.Data.Attributes["display_name"].(string)
+	projectTarget.URL, _ = target.Data.Attributes["url"].(string)
+	return projectTarget
+}
+
+// GetAll gets all projects for the org. If `opts` are given, only the projects matching the first of them are returned.
+func (s *ProjectsService) GetAll(opts ...ListProjectsOptions) ([]Project, error) {
+	return s.GetAllWithContext(context.Background(), opts...)
+}
+
+// GetAllWithContext gets all projects for the org using the provided context. If `opts` are given, only the projects
+// matching the first of them are returned.
+func (s *ProjectsService) GetAllWithContext(ctx context.Context, opts ...ListProjectsOptions) ([]Project, error) {
+	return collectPages(ctx, s.Pager(PageOptions{}, opts...))
+}
+
+// Pager returns a Pager which fetches all projects for the org one page at a time. If `filters` are given, only the
+// projects matching the first of them are returned.
+func (s *ProjectsService) Pager(opts PageOptions, filters ...ListProjectsOptions) *Pager[Project] {
 	path := fmt.Sprintf("/rest/orgs/%s/projects", s.orgID)
 	params := url.Values{}
+	if len(filters) > 0 {
+		params = filters[0].params()
+	}
 	params.Add("meta.latest_dependency_total", "true")
 	params.Add("meta.latest_issue_counts", "true")
-	resources, err := getMultiResource(s.client, path, params)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, r := range resources {
-		projects = append(projects, r.intoProject(s.client, s.orgID))
-	}
 
-	return projects, nil
+	return newPager(s.client, path, params, opts, func(r resource) Project {
+		return r.intoProject(s.client, s.orgID)
+	})
 }
 
 // Get gets the project specified by the given `id`
 func (s *ProjectsService) Get(id string) (Project, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext gets the project specified by the given `id` using the provided context
+func (s *ProjectsService) GetWithContext(ctx context.Context, id string) (Project, error) {
 	var project Project
 	path := fmt.Sprintf("/rest/orgs/%s/projects/%s", s.orgID, id)
-	res, err := getSingleResource(s.client, path, nil)
+	res, err := getSingleResource(ctx, s.client, path, nil)
 	if err != nil {
 		return project, err
 	}
@@ -93,22 +198,190 @@ func (s *ProjectsService) Get(id string) (Project, error) {
 	return res.intoProject(s.client, s.orgID), nil
 }
 
+// ProjectUpdate defines the changes to make to a Project. Fields which are nil are left unchanged, and fields which are
+// set to an empty value are cleared.
+type ProjectUpdate struct {
+	BusinessCriticality []string
+	Environment         []string
+	Lifecycle           []string
+	// The complete set of tags for the project, replacing any existing tags
+	Tags []Tag
+	// The ID of the user who owns the project
+	OwnerID *string
+}
+
+func (u ProjectUpdate) body(projectID string) map[string]any {
+	attributes := map[string]any{}
+	if u.BusinessCriticality != nil {
+		attributes["business_criticality"] = u.BusinessCriticality
+	}
+	if u.Environment != nil {
+		attributes["environment"] = u.Environment
+	}
+	if u.Lifecycle != nil {
+		attributes["lifecycle"] = u.Lifecycle
+	}
+	if u.Tags != nil {
+		attributes["tags"] = u.Tags
+	}
+
+	data := map[string]any{
+		"type":       "project",
+		"id":         projectID,
+		"attributes": attributes,
+	}
+
+	if u.OwnerID != nil {
+		var owner any
+		if *u.OwnerID != "" {
+			owner = map[string]string{"type": "user", "id": *u.OwnerID}
+		}
+		data["relationships"] = map[string]any{"owner": map[string]any{"data": owner}}
+	}
+
+	return map[string]any{"data": data}
+}
+
+// Update updates the attributes, tags and owner of the project. The project is refreshed from the updated project
+// returned by Snyk.
+func (p *Project) Update(update ProjectUpdate) error {
+	return p.UpdateWithContext(context.Background(), update)
+}
+
+// UpdateWithContext updates the attributes, tags and owner of the project using the provided context. The project is
+// refreshed from the updated project returned by Snyk.
+func (p *Project) UpdateWithContext(ctx context.Context, update ProjectUpdate) error {
+	path := fmt.Sprintf("/rest/orgs/%s/projects/%s", p.orgID, p.ID)
+	params := url.Values{}
+	params.Set("version", p.client.APIVersion)
+
+	resp, err := p.client.PatchWithContext(ctx, path, params, update.body(p.ID))
+	if err != nil {
+		return fmt.Errorf("Failed to update project; %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody := singleResourceResp{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return fmt.Errorf("Failed to update project; %w", err)
+	}
+
+	p.refresh(respBody.Data)
+	return nil
+}
+
+// refresh replaces the project's fields with those of `res`. Meta data and the expanded target are kept if the
+// response doesn't include them.
+func (p *Project) refresh(res resource) {
+	updated := res.intoProject(p.client, p.orgID)
+	if updated.Meta == (meta{}) {
+		updated.Meta = p.Meta
+	}
+	if updated.Target == nil {
+		updated.Target = p.Target
+	}
+	*p = updated
+}
+
+// AddTag adds a tag to the project using the v1 API. The project's tags are refreshed from the response.
+func (p *Project) AddTag(tag Tag) error {
+	return p.AddTagWithContext(context.Background(), tag)
+}
+
+// AddTagWithContext adds a tag to the project using the v1 API and the provided context. The project's tags are
+// refreshed from the response.
+func (p *Project) AddTagWithContext(ctx context.Context, tag Tag) error {
+	path := fmt.Sprintf("v1/org/%s/project/%s/tags", p.orgID, p.ID)
+	if err := p.updateTags(ctx, path, tag); err != nil {
+		return fmt.Errorf("Failed to add project tag; %w", err)
+	}
+	return nil
+}
+
+// RemoveTag removes a tag from the project using the v1 API. The project's tags are refreshed from the response.
+func (p *Project) RemoveTag(tag Tag) error {
+	return p.RemoveTagWithContext(context.Background(), tag)
+}
+
+// RemoveTagWithContext removes a tag from the project using the v1 API and the provided context. The project's tags
+// are refreshed from the response.
+func (p *Project) RemoveTagWithContext(ctx context.Context, tag Tag) error {
+	path := fmt.Sprintf("v1/org/%s/project/%s/tags/remove", p.orgID, p.ID)
+	if err := p.updateTags(ctx, path, tag); err != nil {
+		return fmt.Errorf("Failed to remove project tag; %w", err)
+	}
+	return nil
+}
+
+// updateTags sends the tag to one of the v1 tag endpoints, which both respond with the project's resulting tags
+func (p *Project) updateTags(ctx context.Context, path string, tag Tag) error {
+	resp, err := p.client.PostWithContext(ctx, path, nil, tag)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody := struct {
+		Tags []Tag `json:"tags"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return err
+	}
+
+	p.Tags = respBody.Tags
+	return nil
+}
+
 // Delete deletes the given project from Snyk
 func (p *Project) Delete() error {
+	return p.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext deletes the given project from Snyk using the provided context
+func (p *Project) DeleteWithContext(ctx context.Context) error {
 	path := fmt.Sprintf("v1/org/%s/project/%s", p.orgID, p.ID)
-	_, err := p.client.Delete(path, nil)
+	_, err := p.client.DeleteWithContext(ctx, path, nil)
 	return err
 }
 
 // Deactivate deactivates the given project from Snyk
 func (p *Project) Deactivate() error {
+	return p.DeactivateWithContext(context.Background())
+}
+
+// DeactivateWithContext deactivates the given project from Snyk using the provided context
+func (p *Project) DeactivateWithContext(ctx context.Context) error {
 	path := fmt.Sprintf("v1/org/%s/project/%s/deactivate", p.orgID, p.ID)
-	_, err := p.client.Post(path, nil, nil)
+	_, err := p.client.PostWithContext(ctx, path, nil, nil)
 	return err
 }
 
+// Activate activates the given project, re-enabling its recurring tests after it was deactivated
+func (p *Project) Activate() error {
+	return p.ActivateWithContext(context.Background())
+}
+
+// ActivateWithContext activates the given project using the provided context
+func (p *Project) ActivateWithContext(ctx context.Context) error {
+	path := fmt.Sprintf("v1/org/%s/project/%s/activate", p.orgID, p.ID)
+	resp, err := p.client.PostWithContext(ctx, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 // Move moves a project from it's parent org to the provided org
 func (p *Project) Move(targetOrdID string) error {
+	return p.MoveWithContext(context.Background(), targetOrdID)
+}
+
+// MoveWithContext moves a project from it's parent org to the provided org using the provided context
+func (p *Project) MoveWithContext(ctx context.Context, targetOrdID string) error {
 	type reqBody struct {
 		TargetOrgID string `json:"targetOrgId"`
 	}
@@ -116,7 +389,7 @@ func (p *Project) Move(targetOrdID string) error {
 	path := fmt.Sprintf("v1/org/%s/project/%s/move", p.orgID, p.ID)
 	body := reqBody{TargetOrgID: targetOrdID}
 
-	_, err := p.client.Put(path, body)
+	_, err := p.client.PutWithContext(ctx, path, body)
 	return err
 }
 
@@ -0,0 +1,158 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const maxHistoryPageSize = 100
+
+// ProjectSnapshot is the result of a single test of a project
+type ProjectSnapshot struct {
+	ID                string    `json:"id"`
+	Created           time.Time `json:"created"`
+	TotalDependencies int       `json:"totalDependencies"`
+	IssueCounts       struct {
+		Vuln    SeverityCounts `json:"vuln"`
+		License SeverityCounts `json:"license"`
+	} `json:"issueCounts"`
+	// How the project was tested, e.g. `cli`, `recurring` or `web-test`
+	Method string `json:"method"`
+	// Only set for container projects
+	ImageID        string `json:"imageId,omitempty"`
+	ImageTag       string `json:"imageTag,omitempty"`
+	ImageBaseImage string `json:"imageBaseImage,omitempty"`
+	ImagePlatform  string `json:"imagePlatform,omitempty"`
+}
+
+// ProjectHistoryOptions sets which snapshots are returned by Project.History
+type ProjectHistoryOptions struct {
+	// The number of snapshots requested per page (default = 100, max = 100)
+	PerPage int
+	// Only fetch this page, starting at 1. If 0, every page is fetched.
+	Page int
+}
+
+// History gets the snapshots of the project's tests, newest first
+func (p *Project) History(opts ProjectHistoryOptions) ([]ProjectSnapshot, error) {
+	return p.HistoryWithContext(context.Background(), opts)
+}
+
+// HistoryWithContext gets the snapshots of the project's tests, newest first, using the provided context
+func (p *Project) HistoryWithContext(ctx context.Context, opts ProjectHistoryOptions) ([]ProjectSnapshot, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 || perPage > maxHistoryPageSize {
+		perPage = maxHistoryPageSize
+	}
+
+	if opts.Page > 0 {
+		snapshots, _, err := p.historyPage(ctx, opts.Page, perPage)
+		return snapshots, err
+	}
+
+	snapshots := []ProjectSnapshot{}
+	for page := 1; ; page++ {
+		pageSnapshots, total, err := p.historyPage(ctx, page, perPage)
+		if err != nil {
+			if page > 1 {
+				return snapshots, &PartialResultError{Err: err, Pages: page - 1}
+			}
+			return nil, err
+		}
+
+		snapshots = append(snapshots, pageSnapshots...)
+		if len(pageSnapshots) == 0 || len(snapshots) >= total {
+			return snapshots, nil
+		}
+	}
+}
+
+// historyPage fetches a single page of snapshots, along with the total number of snapshots of the project
+func (p *Project) historyPage(ctx context.Context, page, perPage int) ([]ProjectSnapshot, int, error) {
+	urlPath := fmt.Sprintf("/v1/org/%s/project/%s/history", p.orgID, p.ID)
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("perPage", strconv.Itoa(perPage))
+
+	resp, err := p.client.PostWithContext(ctx, urlPath, params, map[string]any{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to get project history; %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody := struct {
+		Snapshots []ProjectSnapshot `json:"snapshots"`
+		Total     int               `json:"total"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to get project history; %w", err)
+	}
+
+	return respBody.Snapshots, respBody.Total, nil
+}
+
+// IssueCountPoint is the issue and dependency counts of a project at a point in time
+type IssueCountPoint struct {
+	Time              time.Time
+	Vuln              SeverityCounts
+	License           SeverityCounts
+	TotalDependencies int
+}
+
+// IssueCountSeries is a time series of the issue counts of a project, oldest first
+type IssueCountSeries []IssueCountPoint
+
+// NewIssueCountSeries converts project snapshots into a time series of issue counts, sorted oldest first
+func NewIssueCountSeries(snapshots []ProjectSnapshot) IssueCountSeries {
+	series := make(IssueCountSeries, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		series = append(series, IssueCountPoint{
+			Time:              snapshot.Created,
+			Vuln:              snapshot.IssueCounts.Vuln,
+			License:           snapshot.IssueCounts.License,
+			TotalDependencies: snapshot.TotalDependencies,
+		})
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Time.Before(series[j].Time)
+	})
+	return series
+}
+
+// Since returns the points at or after `t`
+func (s IssueCountSeries) Since(t time.Time) IssueCountSeries {
+	i := sort.Search(len(s), func(i int) bool {
+		return !s[i].Time.Before(t)
+	})
+	return s[i:]
+}
+
+// Daily returns the last point of each day, in UTC, so that projects tested at different rates can be compared
+func (s IssueCountSeries) Daily() IssueCountSeries {
+	daily := IssueCountSeries{}
+	for _, point := range s {
+		day := point.Time.UTC().Truncate(24 * time.Hour)
+		if len(daily) > 0 && daily[len(daily)-1].Time.UTC().Truncate(24*time.Hour).Equal(day) {
+			daily[len(daily)-1] = point
+		} else {
+			daily = append(daily, point)
+		}
+	}
+	return daily
+}
+
+// Trend returns the change in vulnerability counts from the first to the last point of the series. Negative counts
+// mean the project has fewer issues than it did.
+func (s IssueCountSeries) Trend() SeverityCounts {
+	if len(s) == 0 {
+		return SeverityCounts{}
+	}
+	return s[len(s)-1].Vuln.Sub(s[0].Vuln)
+}
@@ -0,0 +1,117 @@
+package snyk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func testSnapshot(id, created string, critical, high int) map[string]any {
+	return map[string]any{
+		"id":                id,
+		"created":           created,
+		"totalDependencies": 100,
+		"issueCounts": map[string]any{
+			"vuln":    map[string]int{"critical": critical, "high": high, "medium": 0, "low": 1},
+			"license": map[string]int{"critical": 0, "high": 0, "medium": 1, "low": 0},
+		},
+		"method": "recurring",
+	}
+}
+
+func TestProjectHistoryPages(t *testing.T) {
+	defer gock.Off()
+
+	path := fmt.Sprintf("/v1/org/%s/project/%s/history", testOrgID, testProjectID)
+	gock.New(defaultBaseURL).
+		Post(path).
+		MatchParam("page", "1").
+		MatchParam("perPage", "2").
+		Reply(200).
+		JSON(map[string]any{
+			"snapshots": []any{
+				testSnapshot("s3", "2024-03-03T10:00:00.000Z", 0, 1),
+				testSnapshot("s2", "2024-03-02T10:00:00.000Z", 1, 3),
+			},
+			"total": 3,
+		})
+
+	gock.New(defaultBaseURL).
+		Post(path).
+		MatchParam("page", "2").
+		MatchParam("perPage", "2").
+		Reply(200).
+		JSON(map[string]any{
+			"snapshots": []any{testSnapshot("s1", "2024-03-01T10:00:00.000Z", 2, 5)},
+			"total":     3,
+		})
+
+	client := NewClient("mock-token")
+	project := Project{ID: testProjectID, orgID: testOrgID, client: client}
+
+	snapshots, err := project.History(ProjectHistoryOptions{PerPage: 2})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 3, len(snapshots))
+	assert.Equal(t, "s3", snapshots[0].ID)
+	assert.Equal(t, 100, snapshots[0].TotalDependencies)
+	assert.Equal(t, 1, snapshots[0].IssueCounts.License.Medium)
+	assert.Equal(t, "recurring", snapshots[0].Method)
+
+	series := NewIssueCountSeries(snapshots)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), series[0].Time)
+	assert.Equal(t, 8, series[0].Vuln.Total())
+	assert.Equal(t, SeverityCounts{Critical: -2, High: -4}, series.Trend())
+	assert.Equal(t, SeverityCounts{Critical: -1, High: -2}, series.Since(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)).Trend())
+}
+
+func TestProjectHistoryPartialResults(t *testing.T) {
+	defer gock.Off()
+
+	path := fmt.Sprintf("/v1/org/%s/project/%s/history", testOrgID, testProjectID)
+	gock.New(defaultBaseURL).
+		Post(path).
+		MatchParam("page", "1").
+		Reply(200).
+		JSON(map[string]any{
+			"snapshots": []any{testSnapshot("s2", "2024-03-02T10:00:00.000Z", 1, 3)},
+			"total":     2,
+		})
+
+	gock.New(defaultBaseURL).
+		Post(path).
+		MatchParam("page", "2").
+		Reply(404).
+		JSON(map[string]any{"code": 404, "message": "Not found"})
+
+	client := NewClient("mock-token")
+	project := Project{ID: testProjectID, orgID: testOrgID, client: client}
+
+	snapshots, err := project.History(ProjectHistoryOptions{PerPage: 1})
+	var partialErr *PartialResultError
+	assert.True(t, errors.As(err, &partialErr))
+	assert.Equal(t, 1, partialErr.Pages)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, 1, len(snapshots))
+}
+
+func TestIssueCountSeriesDaily(t *testing.T) {
+	day1 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	series := IssueCountSeries{
+		{Time: day1.Add(time.Hour), Vuln: SeverityCounts{High: 3}},
+		{Time: day1.Add(5 * time.Hour), Vuln: SeverityCounts{High: 2}},
+		{Time: day2.Add(time.Hour), Vuln: SeverityCounts{High: 1}},
+	}
+
+	daily := series.Daily()
+	assert.Equal(t, 2, len(daily))
+	assert.Equal(t, 2, daily[0].Vuln.High)
+	assert.Equal(t, 1, daily[1].Vuln.High)
+	assert.Equal(t, SeverityCounts{}, IssueCountSeries{}.Trend())
+}
@@ -0,0 +1,108 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ProjectSettings defines the configurable settings for a Snyk Project. Fields which are nil are inherited from the
+// project's integration and are left unchanged by Project.UpdateSettings.
+type ProjectSettings struct {
+	// Whether Snyk opens pull requests to upgrade dependencies
+	AutoDepUpgradeEnabled *bool `json:"autoDepUpgradeEnabled,omitempty"`
+	// Dependencies which are never upgraded automatically
+	AutoDepUpgradeIgnoredDependencies []string `json:"autoDepUpgradeIgnoredDependencies,omitempty"`
+	// The minimum age in days of a version before it is used for an automatic upgrade
+	AutoDepUpgradeMinAge *int `json:"autoDepUpgradeMinAge,omitempty"`
+	// The maximum number of open automatic upgrade pull requests
+	AutoDepUpgradeLimit *int `json:"autoDepUpgradeLimit,omitempty"`
+	// Whether pull requests are tested for new issues
+	PullRequestTestEnabled *bool `json:"pullRequestTestEnabled,omitempty"`
+	// Whether pull request tests fail when the project has any vulnerabilities, not only newly added ones
+	PullRequestFailOnAnyVulns *bool `json:"pullRequestFailOnAnyVulns,omitempty"`
+	// Whether pull request tests only fail for high and critical severity issues
+	PullRequestFailOnlyForHighSeverity *bool `json:"pullRequestFailOnlyForHighSeverity,omitempty"`
+	// Whether issues which can be fixed by a patch are ignored
+	IgnoreIfPatchable *bool `json:"ignoreIfPatchable,omitempty"`
+	// Settings for the pull requests Snyk opens to fix issues
+	AutoRemediationPrs *AutoRemediationPrs `json:"autoRemediationPrs,omitempty"`
+	// Settings for the project's recurring tests
+	RecurringTests *RecurringTests `json:"recurringTests,omitempty"`
+}
+
+// AutoRemediationPrs defines when Snyk opens pull requests to fix issues in a project
+type AutoRemediationPrs struct {
+	// Whether pull requests are opened for newly found issues
+	FreshPrsEnabled *bool `json:"freshPrsEnabled,omitempty"`
+	// Whether pull requests are opened for existing issues
+	BacklogPrsEnabled *bool `json:"backlogPrsEnabled,omitempty"`
+	// Whether patches are used to fix issues which can't be upgraded
+	UsePatchRemediation *bool `json:"usePatchRemediation,omitempty"`
+}
+
+// RecurringTests defines how often a project is re-tested
+type RecurringTests struct {
+	// Must be one of `daily`, `weekly` or `never`
+	Frequency string `json:"frequency,omitempty"`
+}
+
+// GetSettings returns the currently configured settings for the given project
+func (p *Project) GetSettings() (ProjectSettings, error) {
+	return p.GetSettingsWithContext(context.Background())
+}
+
+// GetSettingsWithContext returns the currently configured settings for the given project using the provided context
+func (p *Project) GetSettingsWithContext(ctx context.Context) (ProjectSettings, error) {
+	urlPath := fmt.Sprintf("/v1/org/%s/project/%s/settings", p.orgID, p.ID)
+	settings := ProjectSettings{}
+	resp, err := p.client.GetWithContext(ctx, urlPath, nil)
+	if err != nil {
+		return settings, fmt.Errorf("Failed to get project settings; %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&settings)
+	if err != nil {
+		return settings, fmt.Errorf("Failed to get project settings; %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings updates the provided settings on the given project. Settings which are nil are left unchanged.
+func (p *Project) UpdateSettings(settings ProjectSettings) error {
+	return p.UpdateSettingsWithContext(context.Background(), settings)
+}
+
+// UpdateSettingsWithContext updates the provided settings on the given project using the provided context
+func (p *Project) UpdateSettingsWithContext(ctx context.Context, settings ProjectSettings) error {
+	urlPath := fmt.Sprintf("/v1/org/%s/project/%s/settings", p.orgID, p.ID)
+
+	resp, err := p.client.PutWithContext(ctx, urlPath, settings)
+	if err != nil {
+		return fmt.Errorf("Failed to update project settings; %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// DeleteSettings deletes the settings configured on the given project, so that it inherits the settings of its
+// integration again
+func (p *Project) DeleteSettings() error {
+	return p.DeleteSettingsWithContext(context.Background())
+}
+
+// DeleteSettingsWithContext deletes the settings configured on the given project using the provided context
+func (p *Project) DeleteSettingsWithContext(ctx context.Context) error {
+	urlPath := fmt.Sprintf("/v1/org/%s/project/%s/settings", p.orgID, p.ID)
+
+	resp, err := p.client.DeleteWithContext(ctx, urlPath, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete project settings; %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
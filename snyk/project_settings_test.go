@@ -0,0 +1,67 @@
+package snyk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func TestProjectGetSettings(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/org/%s/project/%s/settings", testOrgID, testProjectID)).
+		Reply(200).
+		JSON(map[string]any{
+			"autoDepUpgradeEnabled":             false,
+			"autoDepUpgradeIgnoredDependencies": []string{"lodash"},
+			"pullRequestTestEnabled":            true,
+			"autoRemediationPrs":                map[string]any{"freshPrsEnabled": true},
+			"recurringTests":                    map[string]any{"frequency": "weekly"},
+		})
+
+	client := NewClient("mock-token")
+	project := Project{ID: testProjectID, orgID: testOrgID, client: client}
+
+	settings, err := project.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, false, *settings.AutoDepUpgradeEnabled)
+	assert.Equal(t, []string{"lodash"}, settings.AutoDepUpgradeIgnoredDependencies)
+	assert.Equal(t, true, *settings.PullRequestTestEnabled)
+	assert.Zero(t, settings.PullRequestFailOnAnyVulns)
+	assert.Equal(t, true, *settings.AutoRemediationPrs.FreshPrsEnabled)
+	assert.Equal(t, "weekly", settings.RecurringTests.Frequency)
+}
+
+func TestProjectUpdateSettings(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Put(fmt.Sprintf("/v1/org/%s/project/%s/settings", testOrgID, testProjectID)).
+		JSON(map[string]any{
+			"pullRequestFailOnlyForHighSeverity": true,
+			"recurringTests":                     map[string]any{"frequency": "daily"},
+		}).
+		Reply(200).
+		JSON(map[string]any{})
+
+	gock.New(defaultBaseURL).
+		Delete(fmt.Sprintf("/v1/org/%s/project/%s/settings", testOrgID, testProjectID)).
+		Reply(200)
+
+	client := NewClient("mock-token")
+	project := Project{ID: testProjectID, orgID: testOrgID, client: client}
+
+	highOnly := true
+	err := project.UpdateSettings(ProjectSettings{
+		PullRequestFailOnlyForHighSeverity: &highOnly,
+		RecurringTests:                     &RecurringTests{Frequency: "daily"},
+	})
+	assert.NoError(t, err)
+
+	err = project.DeleteSettings()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,154 @@
+package snyk
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const testProjectID = "331ede0a-de94-456f-b788-166caeca58bf"
+
+func TestProjectsGetAllWithFilters(t *testing.T) {
+	defer gock.Off()
+
+	respJSON, err := loadFixture("fixtures/project_get_all_filtered.json")
+	assert.NoError(t, err)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects", testOrgID)).
+		MatchParam("version", defaultAPIVersion).
+		MatchParam("origins", "^github,github-enterprise$").
+		MatchParam("lifecycle", "^production$").
+		MatchParam("tags", "^team:appsec$").
+		MatchParam("names_start_with", "^snyk/$").
+		MatchParam("expand", "^target$").
+		MatchParam("meta.latest_issue_counts", "true").
+		Reply(200).
+		JSON(respJSON)
+
+	client := NewClient("mock-token")
+	projects := ProjectsService{client: client, orgID: testOrgID}
+
+	result, err := projects.GetAll(ListProjectsOptions{
+		Origins:        []string{"github", "github-enterprise"},
+		Lifecycle:      []string{"production"},
+		Tags:           []Tag{{Key: "team", Value: "appsec"}},
+		NamesStartWith: []string{"snyk/"},
+		ExpandTarget:   true,
+	})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 1, len(result))
+
+	project := result[0]
+	assert.Equal(t, "snyk/goof:package.json", project.Name)
+	assert.Equal(t, []Tag{{Key: "team", Value: "appsec"}}, project.Tags)
+	assert.Equal(t, 1, project.Meta.LatestIssueCounts.Critical)
+	assert.Equal(t, &ProjectTarget{
+		ID:          "4bfc4af0-b592-4f71-9b43-d50e8ddd0b51",
+		DisplayName: "snyk/goof",
+		URL:         "https://github.com/snyk/goof",
+	}, project.Target)
+}
+
+func TestProjectsGetAllWithoutFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects", testOrgID)).
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return !req.URL.Query().Has("expand") && !req.URL.Query().Has("origins"), nil
+		}).
+		Reply(200).
+		JSON(map[string]any{"data": []any{}})
+
+	client := NewClient("mock-token")
+	projects := ProjectsService{client: client, orgID: testOrgID}
+
+	result, err := projects.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result))
+	assert.True(t, gock.IsDone())
+}
+
+func TestProjectUpdate(t *testing.T) {
+	defer gock.Off()
+
+	respJSON, err := loadFixture("fixtures/project_update.json")
+	assert.NoError(t, err)
+
+	projectID := testProjectID
+	ownerID := "e661d4ef-5ad5-4cef-ad16-5157cefa83f5"
+
+	gock.New(defaultBaseURL).
+		Patch(fmt.Sprintf("/rest/orgs/%s/projects/%s", testOrgID, projectID)).
+		MatchParam("version", defaultAPIVersion).
+		MatchType("application/vnd.api+json").
+		JSON(map[string]any{
+			"data": map[string]any{
+				"type": "project",
+				"id":   projectID,
+				"attributes": map[string]any{
+					"business_criticality": []string{"high"},
+					"tags":                 []map[string]string{{"key": "owner", "value": "appsec"}},
+				},
+				"relationships": map[string]any{
+					"owner": map[string]any{"data": map[string]string{"type": "user", "id": ownerID}},
+				},
+			},
+		}).
+		Reply(200).
+		JSON(respJSON)
+
+	client := NewClient("mock-token")
+	project := Project{ID: projectID, Lifecycle: []string{"development"}, orgID: testOrgID, client: client}
+	project.Meta.LatestIssueCounts.High = 3
+
+	err = project.Update(ProjectUpdate{
+		BusinessCriticality: []string{"high"},
+		Tags:                []Tag{{Key: "owner", Value: "appsec"}},
+		OwnerID:             &ownerID,
+	})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	assert.Equal(t, []string{"high"}, project.BusinessCriticality)
+	assert.Equal(t, []string{"production"}, project.Lifecycle)
+	assert.Equal(t, []Tag{{Key: "owner", Value: "appsec"}}, project.Tags)
+	assert.Equal(t, ownerID, project.Relationships["owner"].Data.ID)
+	assert.Equal(t, 3, project.Meta.LatestIssueCounts.High)
+	assert.Equal(t, projectID, project.Issues.projectID)
+}
+
+func TestProjectAddAndRemoveTags(t *testing.T) {
+	defer gock.Off()
+
+	projectID := testProjectID
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/org/%s/project/%s/tags", testOrgID, projectID)).
+		JSON(map[string]string{"key": "team", "value": "appsec"}).
+		Reply(200).
+		JSON(map[string]any{"tags": []map[string]string{{"key": "env", "value": "prod"}, {"key": "team", "value": "appsec"}}})
+
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/v1/org/%s/project/%s/tags/remove", testOrgID, projectID)).
+		JSON(map[string]string{"key": "env", "value": "prod"}).
+		Reply(200).
+		JSON(map[string]any{"tags": []map[string]string{{"key": "team", "value": "appsec"}}})
+
+	client := NewClient("mock-token")
+	project := Project{ID: projectID, Tags: []Tag{{Key: "env", Value: "prod"}}, orgID: testOrgID, client: client}
+
+	err := project.AddTag(Tag{Key: "team", Value: "appsec"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Tag{{Key: "env", Value: "prod"}, {Key: "team", Value: "appsec"}}, project.Tags)
+
+	err = project.RemoveTag(Tag{Key: "env", Value: "prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Tag{{Key: "team", Value: "appsec"}}, project.Tags)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,201 @@
+package snyk
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a client-side request budget. Up to `Burst` requests can be sent at once, after which requests are
+// delayed so that no more than `Requests` are sent every `Per`. A RateLimit with no `Requests` is unlimited.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+	// The number of requests that can be sent at once (default = 1)
+	Burst int
+}
+
+// RateLimitStatus is the remaining request quota reported by the `X-RateLimit-*` headers of the most recent response
+// that included them
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	// When the quota resets. This is the zero time if the response didn't include `X-RateLimit-Reset`.
+	Reset time.Time
+	// When the response containing the quota was received. This is the zero time if no quota has been received yet.
+	UpdatedAt time.Time
+}
+
+// RateLimitStatus returns the remaining request quota last reported by the Snyk API
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	return c.rateLimiter.getStatus()
+}
+
+type pathRateLimit struct {
+	pattern string
+	bucket  *tokenBucket
+}
+
+// rateLimiter holds the client-side budgets for each family of endpoints and the in-flight request cap
+type rateLimiter struct {
+	rest     *tokenBucket
+	v1       *tokenBucket
+	paths    []pathRateLimit
+	inFlight chan struct{}
+
+	mu     sync.Mutex
+	status RateLimitStatus
+}
+
+func newRateLimiter() *rateLimiter {
+	l := &rateLimiter{}
+	// The `/v1/group/GROUP_ID/members` endpoint has a rate limit of 1 request per minute
+	// https://snyk.docs.apiary.io/#reference/groups/list-members-in-a-group
+	l.setPathLimit("/v1/group/*/members", RateLimit{Requests: 1, Per: time.Minute})
+	return l
+}
+
+func (l *rateLimiter) setPathLimit(pattern string, limit RateLimit) {
+	pattern = "/" + strings.TrimPrefix(pattern, "/")
+	bucket := newTokenBucket(limit)
+
+	for i, p := range l.paths {
+		if p.pattern == pattern {
+			if bucket == nil {
+				l.paths = append(l.paths[:i], l.paths[i+1:]...)
+			} else {
+				l.paths[i].bucket = bucket
+			}
+			return
+		}
+	}
+
+	if bucket != nil {
+		l.paths = append(l.paths, pathRateLimit{pattern: pattern, bucket: bucket})
+	}
+}
+
+// wait blocks until the request to `urlPath` is within every budget that applies to it. The returned function must be
+// called once the response has been received to release the request's in-flight slot.
+func (l *rateLimiter) wait(ctx context.Context, clock Clock, urlPath string) (func(), error) {
+	apiPath := "/" + strings.TrimPrefix(strings.SplitN(urlPath, "?", 2)[0], "/")
+
+	buckets := []*tokenBucket{l.v1}
+	if strings.HasPrefix(apiPath, "/rest/") {
+		buckets = []*tokenBucket{l.rest}
+	}
+	for _, p := range l.paths {
+		if matched, _ := path.Match(p.pattern, apiPath); matched {
+			buckets = append(buckets, p.bucket)
+		}
+	}
+
+	for _, bucket := range buckets {
+		if bucket == nil {
+			continue
+		}
+		delay := bucket.reserve(clock.Now())
+		if delay <= 0 {
+			continue
+		}
+		if err := clock.Sleep(ctx, delay); err != nil {
+			bucket.cancel()
+			return nil, err
+		}
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// update records the quota reported by the `X-RateLimit-*` headers of a response, if it has any
+func (l *rateLimiter) update(header http.Header, now time.Time) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	status := RateLimitStatus{Remaining: remaining, UpdatedAt: now}
+	status.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// The reset is either a unix timestamp or a number of seconds from now
+		if reset > 1_000_000_000 {
+			status.Reset = time.Unix(reset, 0)
+		} else {
+			status.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	l.mu.Lock()
+	l.status = status
+	l.mu.Unlock()
+}
+
+func (l *rateLimiter) getStatus() RateLimitStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.status
+}
+
+// tokenBucket hands out tokens at a fixed rate. Tokens are reserved ahead of time, so the bucket can go negative when
+// requests are waiting for a token.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Requests <= 0 || limit.Per <= 0 {
+		return nil
+	}
+
+	burst := math.Max(float64(limit.Burst), 1)
+	return &tokenBucket{
+		rate:   float64(limit.Requests) / limit.Per.Seconds(),
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token which was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,83 @@
+package snyk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+func TestRateLimitDelaysRequests(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		Times(3).
+		Reply(200)
+
+	gock.New(defaultBaseURL).
+		Get("/v1/org/org-1/settings").
+		Reply(200)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient(
+		"mock-token",
+		WithClock(clock),
+		WithRESTRateLimit(RateLimit{Requests: 2, Per: time.Second}),
+	)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Get("/rest/orgs", nil)
+		assert.NoError(t, err)
+	}
+	_, err := client.Get("/v1/org/org-1/settings", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, clock.sleeps)
+}
+
+func TestRateLimitDefaultGroupMembersLimit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/v1/group/group-1/members").
+		Times(2).
+		Reply(200).
+		JSON([]any{})
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Users.GetAll("group-1")
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []time.Duration{time.Minute}, clock.sleeps)
+}
+
+func TestRateLimitStatus(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		Reply(200).
+		SetHeader("X-RateLimit-Limit", "1620").
+		SetHeader("X-RateLimit-Remaining", "1619").
+		SetHeader("X-RateLimit-Reset", "60")
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := NewClient("mock-token", WithClock(&fakeClock{now: now}))
+	assert.Equal(t, RateLimitStatus{}, client.RateLimitStatus())
+
+	_, err := client.Get("/rest/orgs", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, RateLimitStatus{
+		Limit:     1620,
+		Remaining: 1619,
+		Reset:     now.Add(time.Minute),
+		UpdatedAt: now,
+	}, client.RateLimitStatus())
+}
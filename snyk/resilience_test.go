@@ -0,0 +1,112 @@
+package snyk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+	"snyk/Application-Security/snyk-sdk/snyktest"
+)
+
+// recordingClock records retry delays without sleeping
+type recordingClock struct {
+	sleeps []time.Duration
+}
+
+func (c *recordingClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *recordingClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	return ctx.Err()
+}
+
+func newFaultyClient(t *testing.T, projects int) (snyk.Org, *snyktest.FaultTransport, *recordingClock) {
+	t.Helper()
+
+	server := snyktest.NewServer()
+	t.Cleanup(server.Close)
+
+	org := server.AddOrg(snyktest.Org{Name: "org1"})
+	for i := 0; i < projects; i++ {
+		server.AddProject(org.ID, snyktest.Project{Name: "project", Type: "npm"})
+	}
+
+	faults := snyktest.NewFaultTransport(nil)
+	clock := &recordingClock{}
+	client := server.Client(snyk.WithTransport(faults), snyk.WithClock(clock))
+
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	return snykOrg, faults, clock
+}
+
+func TestRetriesRateLimitedRequests(t *testing.T) {
+	org, faults, clock := newFaultyClient(t, 1)
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Times: 2, Fault: snyktest.RateLimited(3 * time.Second)})
+
+	projects, err := org.Projects.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(projects))
+	assert.Equal(t, 2, faults.Injected())
+	// Retry-After plus the default padding
+	assert.Equal(t, []time.Duration{8 * time.Second, 8 * time.Second}, clock.sleeps)
+}
+
+func TestRetriesServerErrorsAndConnectionResets(t *testing.T) {
+	org, faults, clock := newFaultyClient(t, 1)
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Call: 1, Fault: snyktest.ServerError(http.StatusBadGateway)})
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Call: 2, Fault: snyktest.ConnectionReset()})
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Call: 3, Fault: snyktest.ServerError(http.StatusServiceUnavailable)})
+
+	projects, err := org.Projects.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(projects))
+	assert.Equal(t, 3, len(clock.sleeps))
+}
+
+func TestGivesUpAfterMaxRetries(t *testing.T) {
+	org, faults, _ := newFaultyClient(t, 1)
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Fault: snyktest.ConnectionReset()})
+
+	_, err := org.Projects.GetAll()
+	assert.True(t, errors.Is(err, syscall.ECONNRESET))
+	// The initial attempt and the default 6 retries
+	assert.Equal(t, 7, faults.Injected())
+}
+
+func TestTruncatedPageReturnsPartialResults(t *testing.T) {
+	org, faults, _ := newFaultyClient(t, 150)
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Call: 2, Fault: snyktest.TruncatedBody()})
+
+	projects, err := org.Projects.GetAll()
+	var partialErr *snyk.PartialResultError
+	assert.True(t, errors.As(err, &partialErr))
+	assert.Equal(t, 1, partialErr.Pages)
+	assert.NotEqual(t, "", partialErr.Cursor)
+	assert.Equal(t, 100, len(projects))
+
+	pager := org.Projects.Pager(snyk.PageOptions{Limit: 100, StartingAfter: partialErr.Cursor})
+	page, err := pager.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 50, len(page))
+}
+
+func TestSlowResponseHonoursDeadline(t *testing.T) {
+	org, faults, _ := newFaultyClient(t, 1)
+	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Fault: snyktest.SlowResponse(time.Minute)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := org.Projects.GetAllWithContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, faults.Injected())
+}
@@ -1,10 +1,9 @@
 package snyk
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -26,7 +25,7 @@ type resource struct {
 		BusinessCriticality []string       `json:"business_criticality,omitempty"`
 		Environment         []string       `json:"environment,omitempty"`
 		Lifecycle           []string       `json:"lifecycle,omitempty"`
-		Tags                []tag          `json:"tags,omitempty"`
+		Tags                []Tag          `json:"tags,omitempty"`
 		ReadOnly            bool           `json:"read_only,omitempty"`
 		Settings            map[string]any `json:"settings,omitempty"`
 		IsPrivate           bool           `json:"isPrivate,omitempty"`
@@ -58,6 +57,11 @@ type resource struct {
 		Layers   []string `json:"layers"`
 		Names    []string `json:"names"`
 		Platform string   `json:"platform"`
+
+		// OrgInvite
+		Email    string `json:"email,omitempty"`
+		Role     string `json:"role,omitempty"`
+		IsActive bool   `json:"is_active,omitempty"`
 	} `json:"attributes"`
 	Meta          meta                    `json:"meta,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
@@ -88,7 +92,8 @@ type meta struct {
 	} `json:"latest_issue_counts,omitempty"`
 }
 
-type tag struct {
+// Tag is a key/value pair used to group and filter Projects
+type Tag struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
@@ -105,6 +110,8 @@ type Data struct {
 	Type      string    `json:"type"`
 	Source    string    `json:"source,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// The attributes of a related resource, only set when the relationship is expanded
+	Attributes map[string]any `json:"attributes,omitempty"`
 }
 
 type links struct {
@@ -126,68 +133,14 @@ type primaryRegion struct {
 	EndColumn   int `json:"endColumn"`
 }
 
-func getMultiResource(client *Client, path string, addlParams url.Values) ([]resource, error) {
-	var resources []resource
-	params := url.Values{}
-	if !addlParams.Has("version") {
-		params.Set("version", client.APIVersion)
-	}
-	params.Set("limit", "100")
-
-	if addlParams != nil {
-		for k, vSlice := range addlParams {
-			for _, v := range vSlice {
-				params.Set(k, v)
-			}
-		}
-	}
-
-	apiVersion := params.Get("version")
-
-	urlPath := path
-
-	for {
-		resp, err := client.Get(urlPath, params)
-		if err != nil {
-			return nil, err
-		}
-
-		respBody := multiResourceResp{}
-		err = json.NewDecoder(resp.Body).Decode(&respBody)
-		if err != nil {
-			return nil, err
-		}
-
-		resources = append(resources, respBody.Data...)
-
-		if respBody.Links.Next == "" || len(respBody.Data) == 0 {
-			break
-		}
-
-		urlPath = respBody.Links.Next
-
-		// Some versions of the Snyk REST API have "/rest" at the beginning of the "next" URL path... some don't...
-		if !strings.HasPrefix(urlPath, "/rest") {
-			urlPath = fmt.Sprintf("/rest/%s", urlPath)
-		}
-
-		params = nil
-
-		// Some versions of the Snyk REST API include the 'version' param from the original request in the "next" URL path... some don't...
-		requestURL, err := getReqURL(urlPath)
-		if err != nil {
-			return nil, err
-		}
-		if requestURL.Query().Get("version") == "" {
-			params = requestURL.Query()
-			params.Set("version", apiVersion)
-		}
-	}
-
-	return resources, nil
+// getMultiResource fetches every page of a REST list endpoint. If fetching fails after at least one page was fetched,
+// the resources fetched so far are returned along with a `*PartialResultError`.
+func getMultiResource(ctx context.Context, client *Client, path string, addlParams url.Values) ([]resource, error) {
+	pager := newPager(client, path, addlParams, PageOptions{}, func(r resource) resource { return r })
+	return collectPages(ctx, pager)
 }
 
-func getSingleResource(client *Client, path string, addlParams url.Values) (resource, error) {
+func getSingleResource(ctx context.Context, client *Client, path string, addlParams url.Values) (resource, error) {
 	var res resource
 	params := url.Values{}
 	if !addlParams.Has("version") {
@@ -204,7 +157,7 @@ func getSingleResource(client *Client, path string, addlParams url.Values) (reso
 
 	urlPath := path
 
-	resp, err := client.Get(urlPath, params)
+	resp, err := client.GetWithContext(ctx, urlPath, params)
 	if err != nil {
 		return res, err
 	}
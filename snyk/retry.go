@@ -0,0 +1,165 @@
+package snyk
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAttempt describes a failed attempt at sending a request
+type RetryAttempt struct {
+	// The number of retries that have already been made for the request. This is 0 after the first attempt.
+	Attempt int
+	// The request that was sent
+	Request *http.Request
+	// The response that was received. This is nil if the request failed with a transport error.
+	Response *http.Response
+	// The transport error returned when sending the request, if any
+	Err error
+	// The current time according to the client's Clock
+	Now time.Time
+}
+
+// RetryPolicy decides whether a failed request is retried and how long to wait before retrying it. The client stops
+// retrying once the limit set by SetMaxRetries is reached, regardless of the policy.
+type RetryPolicy interface {
+	// Retry returns the delay to wait before retrying the request, and whether it should be retried at all
+	Retry(attempt RetryAttempt) (time.Duration, bool)
+}
+
+// Clock tells the time and waits. It can be replaced using WithClock so that tests don't have to wait for retries.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for the duration `d` to pass, returning early with `ctx.Err()` if `ctx` is done first
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// BackoffPolicy is a RetryPolicy which retries with jittered exponential backoff. The delay before retry `i` is
+// picked randomly between half and all of `BaseDelay * 2^i`, and is capped at `MaxDelay`. When the response has a
+// `Retry-After` header, in either seconds or HTTP-date form, it is used instead.
+//
+// Requests which were rejected with a 429 are retried regardless of their method since the server did not process
+// them. Other failures are only retried for idempotent methods unless `RetryNonIdempotent` is set.
+type BackoffPolicy struct {
+	// The delay before the first retry
+	BaseDelay time.Duration
+	// The maximum delay between retries, not including delays requested by `Retry-After`
+	MaxDelay time.Duration
+	// Additional time added to any `Retry-After` delay
+	RetryAfterPadding time.Duration
+	// The response status codes which are retried
+	RetryStatusCodes []int
+	// Retry methods which are not idempotent, such as POST and PATCH
+	RetryNonIdempotent bool
+	// Rand returns a random number in [0.0, 1.0) used to jitter delays. Defaults to `rand.Float64`.
+	Rand func() float64
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by clients unless one is set with WithRetryPolicy
+func DefaultRetryPolicy() *BackoffPolicy {
+	return &BackoffPolicy{
+		BaseDelay: time.Second,
+		MaxDelay:  64 * time.Second,
+		// The `/v1/groups/GROUP_ID/members` endpoint has a rate limit of 1 request per minute
+		// It also seems to accumulate that wait time if multiple requests are made within the timeout
+		// https://snyk.docs.apiary.io/#reference/groups/list-members-in-a-group
+		RetryAfterPadding: 5 * time.Second,
+		RetryStatusCodes:  []int{429, 500, 502, 503, 504},
+	}
+}
+
+// Retry implements RetryPolicy
+func (p *BackoffPolicy) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	if attempt.Response == nil {
+		if attempt.Err == nil || !p.canRetryMethod(attempt.Request.Method) {
+			return 0, false
+		}
+		return p.backoff(attempt.Attempt), true
+	}
+
+	statusCode := attempt.Response.StatusCode
+	if !isInSlice(statusCode, p.RetryStatusCodes) {
+		return 0, false
+	}
+	if statusCode != http.StatusTooManyRequests && !p.canRetryMethod(attempt.Request.Method) {
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(attempt.Response.Header.Get("Retry-After"), attempt.Now); ok {
+		return retryAfter + p.RetryAfterPadding, true
+	}
+
+	return p.backoff(attempt.Attempt), true
+}
+
+func (p *BackoffPolicy) canRetryMethod(method string) bool {
+	return p.RetryNonIdempotent || isIdempotent(method)
+}
+
+func (p *BackoffPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Exp2(float64(attempt))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	random := rand.Float64
+	if p.Rand != nil {
+		random = p.Rand
+	}
+
+	return time.Duration(delay/2 + random()*delay/2)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses the value of a `Retry-After` header, which is either a number of seconds or an HTTP-date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := date.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
@@ -0,0 +1,120 @@
+package snyk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+func TestRetryHonoursMaxRetries(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get("/rest/orgs").
+		Times(3).
+		Reply(500)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+	client.SetMaxRetries(2)
+
+	_, err := client.Get("/rest/orgs", nil)
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(clock.sleeps))
+	assert.True(t, gock.IsDone())
+}
+
+func TestRetryReplaysRequestBody(t *testing.T) {
+	defer gock.Off()
+
+	body := map[string]string{"targetOrgId": "org-2"}
+
+	gock.New(defaultBaseURL).
+		Post("/v1/org/org-1/project/project-1/move").
+		JSON(body).
+		Reply(429).
+		SetHeader("Retry-After", "2")
+
+	gock.New(defaultBaseURL).
+		Post("/v1/org/org-1/project/project-1/move").
+		JSON(body).
+		Reply(200)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+
+	_, err := client.Post("/v1/org/org-1/project/project-1/move", nil, body)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, clock.sleeps)
+	assert.True(t, gock.IsDone())
+}
+
+func TestRetrySkipsNonIdempotentMethods(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Post("/v1/org").
+		Reply(500)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+
+	_, err := client.Post("/v1/org", nil, map[string]string{"name": "org"})
+	assert.Error(t, err)
+	assert.Equal(t, 0, len(clock.sleeps))
+}
+
+func TestBackoffPolicyDelay(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.Rand = func() float64 { return 1 }
+	req, _ := http.NewRequest(http.MethodGet, defaultBaseURL, nil)
+	resp := &http.Response{StatusCode: 503, Header: http.Header{}}
+
+	delay, retry := policy.Retry(RetryAttempt{Attempt: 3, Request: req, Response: resp})
+	assert.True(t, retry)
+	assert.Equal(t, 8*time.Second, delay)
+
+	delay, _ = policy.Retry(RetryAttempt{Attempt: 10, Request: req, Response: resp})
+	assert.Equal(t, 64*time.Second, delay)
+
+	policy.Rand = func() float64 { return 0 }
+	delay, _ = policy.Retry(RetryAttempt{Attempt: 3, Request: req, Response: resp})
+	assert.Equal(t, 4*time.Second, delay)
+
+	_, retry = policy.Retry(RetryAttempt{Request: req, Response: &http.Response{StatusCode: 404}})
+	assert.False(t, retry)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("30", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	delay, ok = parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, delay)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
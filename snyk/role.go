@@ -0,0 +1,142 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRole is returned, before any request is sent, when a role name or ID isn't valid for the request
+var ErrInvalidRole = errors.New("snyk: invalid role")
+
+// The roles accepted by Group.AddUserToOrg
+const (
+	OrgRoleAdmin        = "admin"
+	OrgRoleCollaborator = "collaborator"
+)
+
+// The names of the org roles which every group has. Any other role is a custom role.
+var builtInRoleNames = []string{"Org Admin", "Org Collaborator"}
+
+// The names of Snyk's built-in group roles, which are given to group members and can't be given to org members
+var groupRoleNames = []string{"Group Admin", "Group Member", "Group Viewer"}
+
+// Role is a role which can be given to the members of the orgs in a group. The permissions granted by a role aren't
+// included, as no Snyk API endpoint available to the client returns them.
+type Role struct {
+	// The public ID of the role, which is used to assign it
+	ID          string    `json:"publicId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Created     time.Time `json:"created"`
+	Modified    time.Time `json:"modified"`
+}
+
+// IsBuiltIn returns whether the role is one of Snyk's built-in roles rather than a custom role
+func (r Role) IsBuiltIn() bool {
+	return isInSlice(r.Name, builtInRoleNames) || isGroupRoleName(r.Name)
+}
+
+// IsOrgRole returns whether the role can be given to the members of an org, rather than only to group members
+func (r Role) IsOrgRole() bool {
+	return !isGroupRoleName(r.Name)
+}
+
+// isGroupRoleName returns whether `name` is one of the built-in group roles, ignoring case
+func isGroupRoleName(name string) bool {
+	for _, groupRoleName := range groupRoleNames {
+		if strings.EqualFold(name, groupRoleName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles gets the built-in and custom roles of the group. The roles don't include their permissions, which the v1 roles
+// endpoint doesn't return.
+func (g *Group) Roles() ([]Role, error) {
+	return g.RolesWithContext(context.Background())
+}
+
+// RolesWithContext gets the built-in and custom roles of the group using the provided context
+func (g *Group) RolesWithContext(ctx context.Context) ([]Role, error) {
+	urlPath := fmt.Sprintf("/v1/group/%s/roles", g.ID)
+
+	resp, err := g.client.GetWithContext(ctx, urlPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get group roles; %w", err)
+	}
+	defer resp.Body.Close()
+
+	roles := []Role{}
+	err = json.NewDecoder(resp.Body).Decode(&roles)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get group roles; %w", err)
+	}
+
+	return roles, nil
+}
+
+// RoleByName gets the role of the group with the given name, ignoring case. ErrInvalidRole is returned if the group
+// has no such role.
+func (g *Group) RoleByName(name string) (Role, error) {
+	return g.RoleByNameWithContext(context.Background(), name)
+}
+
+// RoleByNameWithContext gets the role of the group with the given name using the provided context
+func (g *Group) RoleByNameWithContext(ctx context.Context, name string) (Role, error) {
+	roles, err := g.RolesWithContext(ctx)
+	if err != nil {
+		return Role{}, err
+	}
+
+	return FindRole(roles, name)
+}
+
+// FindRole returns the role with the given name, ignoring case. The error lists the valid names if none match.
+func FindRole(roles []Role, name string) (Role, error) {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if strings.EqualFold(role.Name, name) {
+			return role, nil
+		}
+		names = append(names, fmt.Sprintf("%q", role.Name))
+	}
+
+	return Role{}, fmt.Errorf("%w: %q is not a role in the group; valid roles are %s", ErrInvalidRole, name, strings.Join(names, ", "))
+}
+
+// UpdateUserRoleByName sets the given `user`'s role in the org to the group role with the given name. Group roles such
+// as `Group Admin`, which can't be given in an org, are rejected before any request is sent. Other names are resolved
+// with Group.RoleByName, so unknown names are rejected before the user's role is changed.
+func (g *Group) UpdateUserRoleByName(org Org, user User, roleName string) error {
+	return g.UpdateUserRoleByNameWithContext(context.Background(), org, user, roleName)
+}
+
+// UpdateUserRoleByNameWithContext sets the given `user`'s role in the org to the group role with the given name using
+// the provided context
+func (g *Group) UpdateUserRoleByNameWithContext(ctx context.Context, org Org, user User, roleName string) error {
+	if isGroupRoleName(roleName) {
+		return fmt.Errorf("Failed to update user role; %w: %q is a group role, which can't be given to org members", ErrInvalidRole, roleName)
+	}
+
+	role, err := g.RoleByNameWithContext(ctx, roleName)
+	if err != nil {
+		return fmt.Errorf("Failed to update user role; %w", err)
+	}
+
+	// The request is sent with the group's client, as AddUserToOrg does, so orgs which only have an ID set work too
+	org.client = g.client
+	return org.UpdateUserRoleWithContext(ctx, user, role.ID)
+}
+
+// validateOrgRole checks that `role` is accepted by Group.AddUserToOrg
+func validateOrgRole(role string) error {
+	if role != OrgRoleAdmin && role != OrgRoleCollaborator {
+		return fmt.Errorf("%w: %q must be %q or %q", ErrInvalidRole, role, OrgRoleAdmin, OrgRoleCollaborator)
+	}
+	return nil
+}
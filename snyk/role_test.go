@@ -0,0 +1,125 @@
+package snyk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const testCustomRoleID = "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d"
+
+func mockGroupRoles() {
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/v1/group/%s/roles", testGroupID)).
+		Reply(200).
+		JSON([]map[string]any{
+			{
+				"name":        "Org Admin",
+				"description": "Org Admin",
+				"publicId":    testOrgAdminRole,
+				"created":     "2021-04-22T09:41:12.000Z",
+				"modified":    "2021-04-22T09:41:12.000Z",
+			},
+			{
+				"name":        "Security Reviewer",
+				"description": "Can view and ignore issues",
+				"publicId":    testCustomRoleID,
+				"created":     "2023-02-01T10:00:00.000Z",
+				"modified":    "2023-02-01T10:00:00.000Z",
+			},
+			{
+				"name":        "Group Admin",
+				"description": "Group Admin",
+				"publicId":    "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+				"created":     "2021-04-22T09:41:12.000Z",
+				"modified":    "2021-04-22T09:41:12.000Z",
+			},
+		})
+}
+
+func TestGroupRoles(t *testing.T) {
+	defer gock.Off()
+
+	mockGroupRoles()
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	roles, err := group.Roles()
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 3, len(roles))
+	assert.True(t, roles[0].IsBuiltIn())
+	assert.False(t, roles[1].IsBuiltIn())
+	assert.Equal(t, testCustomRoleID, roles[1].ID)
+	assert.True(t, roles[1].IsOrgRole())
+	assert.True(t, roles[2].IsBuiltIn())
+	assert.False(t, roles[2].IsOrgRole())
+}
+
+func TestFindRole(t *testing.T) {
+	roles := []Role{{ID: testOrgAdminRole, Name: "Org Admin"}, {ID: testCustomRoleID, Name: "Security Reviewer"}}
+
+	role, err := FindRole(roles, "security reviewer")
+	assert.NoError(t, err)
+	assert.Equal(t, testCustomRoleID, role.ID)
+
+	_, err = FindRole(roles, "Owner")
+	assert.True(t, errors.Is(err, ErrInvalidRole))
+	assert.Contains(t, err.Error(), `"Org Admin", "Security Reviewer"`)
+}
+
+func TestGroupUpdateUserRoleByName(t *testing.T) {
+	defer gock.Off()
+
+	mockGroupRoles()
+	gock.New(defaultBaseURL).
+		Put(fmt.Sprintf("/v1/org/%s/members/update/%s", testOrgID, testUserID)).
+		JSON(map[string]string{"rolePublicId": testCustomRoleID}).
+		Reply(200)
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	err := group.UpdateUserRoleByName(Org{ID: testOrgID}, User{ID: testUserID}, "Security Reviewer")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGroupUpdateUserRoleByNameRejectsUnknownRole(t *testing.T) {
+	defer gock.Off()
+
+	mockGroupRoles()
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	err := group.UpdateUserRoleByName(Org{ID: testOrgID}, User{ID: testUserID}, "Owner")
+	assert.True(t, errors.Is(err, ErrInvalidRole))
+	assert.True(t, gock.IsDone())
+}
+
+func TestGroupUpdateUserRoleByNameRejectsGroupRole(t *testing.T) {
+	defer gock.Off()
+
+	// "Group Admin" is a role in the group, but not one which can be given in an org
+	mockGroupRoles()
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	err := group.UpdateUserRoleByName(Org{ID: testOrgID}, User{ID: testUserID}, "group admin")
+	assert.True(t, errors.Is(err, ErrInvalidRole))
+	assert.Contains(t, err.Error(), "group role")
+	assert.True(t, gock.IsPending())
+	assert.False(t, gock.HasUnmatchedRequest())
+}
+
+func TestGroupAddUserToOrgRejectsInvalidRole(t *testing.T) {
+	defer gock.Off()
+
+	group := Group{ID: testGroupID, client: NewClient("mock-token")}
+
+	err := group.AddUserToOrg(Org{ID: testOrgID}, User{ID: testUserID}, "Org Admin")
+	assert.True(t, errors.Is(err, ErrInvalidRole))
+	assert.False(t, gock.HasUnmatchedRequest())
+}
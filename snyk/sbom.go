@@ -0,0 +1,231 @@
+package snyk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// SBOMFormat is a format which a software bill of materials can be exported in
+type SBOMFormat string
+
+// The SBOM formats supported by the Snyk API
+const (
+	SBOMFormatCycloneDX14JSON SBOMFormat = "cyclonedx1.4+json"
+	SBOMFormatCycloneDX14XML  SBOMFormat = "cyclonedx1.4+xml"
+	SBOMFormatCycloneDX15JSON SBOMFormat = "cyclonedx1.5+json"
+	SBOMFormatCycloneDX15XML  SBOMFormat = "cyclonedx1.5+xml"
+	SBOMFormatSPDX23JSON      SBOMFormat = "spdx2.3+json"
+)
+
+// SBOMComponent is a package listed in a software bill of materials
+type SBOMComponent struct {
+	Name    string
+	Version string
+	// The package URL, e.g. `pkg:npm/lodash@4.17.21`
+	PURL     string
+	Licenses []string
+}
+
+// SBOM writes the software bill of materials of the project to `w` in the given format. The document is streamed
+// as it is received, without being held in memory.
+func (p *Project) SBOM(ctx context.Context, format SBOMFormat, w io.Writer) error {
+	urlPath := fmt.Sprintf("/rest/orgs/%s/projects/%s/sbom", p.orgID, p.ID)
+	params := url.Values{}
+	params.Set("version", p.client.APIVersion)
+	params.Set("format", string(format))
+
+	resp, err := p.client.GetWithContext(ctx, urlPath, params)
+	if err != nil {
+		return fmt.Errorf("Failed to get project SBOM; %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("Failed to get project SBOM; %w", err)
+	}
+
+	return nil
+}
+
+// SBOMComponents gets the software bill of materials of the project in the given format and parses the components
+// listed in it
+func (p *Project) SBOMComponents(ctx context.Context, format SBOMFormat) ([]SBOMComponent, error) {
+	var sbom bytes.Buffer
+	if err := p.SBOM(ctx, format, &sbom); err != nil {
+		return nil, err
+	}
+
+	return ParseSBOMComponents(&sbom, format)
+}
+
+// ExportSBOMs writes the software bill of materials of each project in the org which matches `opts` in the given
+// format. `create` is called to open the destination of each project's SBOM, which is closed once it is written.
+// Projects whose SBOM can't be exported, such as code analysis projects, don't stop the export. Their errors are
+// returned together once every project has been tried.
+func (s *ProjectsService) ExportSBOMs(
+	ctx context.Context,
+	format SBOMFormat,
+	opts ListProjectsOptions,
+	create func(Project) (io.WriteCloser, error),
+) error {
+	var errs []error
+	pager := s.Pager(PageOptions{}, opts)
+	for pager.More() {
+		projects, err := pager.Next(ctx)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		for _, project := range projects {
+			if err := exportSBOM(ctx, project, format, create); err != nil {
+				errs = append(errs, fmt.Errorf("project %s: %w", project.ID, err))
+			}
+			if ctx.Err() != nil {
+				return errors.Join(append(errs, ctx.Err())...)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func exportSBOM(ctx context.Context, project Project, format SBOMFormat, create func(Project) (io.WriteCloser, error)) error {
+	w, err := create(project)
+	if err != nil {
+		return err
+	}
+
+	err = project.SBOM(ctx, format, w)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ParseSBOMComponents parses the components listed in a software bill of materials in the given format. Components
+// nested in other CycloneDX components are included.
+func ParseSBOMComponents(r io.Reader, format SBOMFormat) ([]SBOMComponent, error) {
+	switch format {
+	case SBOMFormatCycloneDX14JSON, SBOMFormatCycloneDX15JSON:
+		bom := cycloneDXBOM{}
+		if err := json.NewDecoder(r).Decode(&bom); err != nil {
+			return nil, fmt.Errorf("Failed to parse SBOM; %w", err)
+		}
+		return flattenCycloneDXComponents(bom.Components), nil
+
+	case SBOMFormatCycloneDX14XML, SBOMFormatCycloneDX15XML:
+		bom := cycloneDXBOM{}
+		if err := xml.NewDecoder(r).Decode(&bom); err != nil {
+			return nil, fmt.Errorf("Failed to parse SBOM; %w", err)
+		}
+		return flattenCycloneDXComponents(bom.Components), nil
+
+	case SBOMFormatSPDX23JSON:
+		doc := spdxDocument{}
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("Failed to parse SBOM; %w", err)
+		}
+		return doc.components(), nil
+
+	default:
+		return nil, fmt.Errorf("Failed to parse SBOM; unsupported format %q", format)
+	}
+}
+
+// cycloneDXBOM is the part of a CycloneDX document which lists components, in either its JSON or XML encoding
+type cycloneDXBOM struct {
+	Components []cycloneDXComponent `json:"components" xml:"components>component"`
+}
+
+type cycloneDXComponent struct {
+	Name     string `json:"name" xml:"name"`
+	Version  string `json:"version" xml:"version"`
+	PURL     string `json:"purl" xml:"purl"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses" xml:"-"`
+	XMLLicenses struct {
+		Licenses []struct {
+			ID   string `xml:"id"`
+			Name string `xml:"name"`
+		} `xml:"license"`
+		Expressions []string `xml:"expression"`
+	} `json:"-" xml:"licenses"`
+	Components []cycloneDXComponent `json:"components" xml:"components>component"`
+}
+
+func flattenCycloneDXComponents(components []cycloneDXComponent) []SBOMComponent {
+	flattened := []SBOMComponent{}
+	for _, c := range components {
+		component := SBOMComponent{Name: c.Name, Version: c.Version, PURL: c.PURL}
+		for _, l := range c.Licenses {
+			component.Licenses = appendLicense(component.Licenses, l.License.ID, l.License.Name, l.Expression)
+		}
+		for _, l := range c.XMLLicenses.Licenses {
+			component.Licenses = appendLicense(component.Licenses, l.ID, l.Name)
+		}
+		for _, expression := range c.XMLLicenses.Expressions {
+			component.Licenses = appendLicense(component.Licenses, expression)
+		}
+
+		flattened = append(flattened, component)
+		flattened = append(flattened, flattenCycloneDXComponents(c.Components)...)
+	}
+	return flattened
+}
+
+// appendLicense appends the first of `names` which isn't empty
+func appendLicense(licenses []string, names ...string) []string {
+	for _, name := range names {
+		if name != "" {
+			return append(licenses, name)
+		}
+	}
+	return licenses
+}
+
+type spdxDocument struct {
+	Packages []struct {
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func (d spdxDocument) components() []SBOMComponent {
+	components := []SBOMComponent{}
+	for _, pkg := range d.Packages {
+		component := SBOMComponent{Name: pkg.Name, Version: pkg.VersionInfo}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				component.PURL = ref.ReferenceLocator
+			}
+		}
+		// NOASSERTION and NONE mean the license is unknown
+		license := pkg.LicenseConcluded
+		if license == "" || license == "NOASSERTION" || license == "NONE" {
+			license = pkg.LicenseDeclared
+		}
+		if license != "" && license != "NOASSERTION" && license != "NONE" {
+			component.Licenses = []string{license}
+		}
+		components = append(components, component)
+	}
+	return components
+}
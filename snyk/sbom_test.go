@@ -0,0 +1,157 @@
+package snyk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const testCycloneDXJSON = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {
+      "type": "library",
+      "name": "express",
+      "version": "4.12.4",
+      "purl": "pkg:npm/express@4.12.4",
+      "licenses": [{"license": {"id": "MIT"}}],
+      "components": [
+        {"type": "library", "name": "qs", "version": "2.4.2", "purl": "pkg:npm/qs@2.4.2", "licenses": [{"expression": "BSD-3-Clause OR MIT"}]}
+      ]
+    }
+  ]
+}`
+
+const testCycloneDXXML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <components>
+    <component type="library">
+      <name>express</name>
+      <version>4.12.4</version>
+      <purl>pkg:npm/express@4.12.4</purl>
+      <licenses>
+        <license><id>MIT</id></license>
+      </licenses>
+    </component>
+  </components>
+</bom>`
+
+const testSPDXJSON = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {
+      "name": "express",
+      "versionInfo": "4.12.4",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "MIT",
+      "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/express@4.12.4"}]
+    }
+  ]
+}`
+
+func TestProjectSBOM(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects/%s/sbom", testOrgID, testProjectID)).
+		MatchParam("version", defaultAPIVersion).
+		MatchParam("format", `^cyclonedx1\.5\+json$`).
+		Times(2).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.cyclonedx+json").
+		BodyString(testCycloneDXJSON)
+
+	client := NewClient("mock-token")
+	project := Project{ID: testProjectID, orgID: testOrgID, client: client}
+
+	var sbom bytes.Buffer
+	err := project.SBOM(context.Background(), SBOMFormatCycloneDX15JSON, &sbom)
+	assert.NoError(t, err)
+	assert.Equal(t, testCycloneDXJSON, sbom.String())
+
+	components, err := project.SBOMComponents(context.Background(), SBOMFormatCycloneDX15JSON)
+	assert.NoError(t, err)
+	assert.Equal(t, []SBOMComponent{
+		{Name: "express", Version: "4.12.4", PURL: "pkg:npm/express@4.12.4", Licenses: []string{"MIT"}},
+		{Name: "qs", Version: "2.4.2", PURL: "pkg:npm/qs@2.4.2", Licenses: []string{"BSD-3-Clause OR MIT"}},
+	}, components)
+	assert.True(t, gock.IsDone())
+}
+
+func TestParseSBOMComponents(t *testing.T) {
+	express := SBOMComponent{Name: "express", Version: "4.12.4", PURL: "pkg:npm/express@4.12.4", Licenses: []string{"MIT"}}
+
+	components, err := ParseSBOMComponents(strings.NewReader(testCycloneDXXML), SBOMFormatCycloneDX14XML)
+	assert.NoError(t, err)
+	assert.Equal(t, []SBOMComponent{express}, components)
+
+	components, err = ParseSBOMComponents(strings.NewReader(testSPDXJSON), SBOMFormatSPDX23JSON)
+	assert.NoError(t, err)
+	assert.Equal(t, []SBOMComponent{express}, components)
+
+	_, err = ParseSBOMComponents(strings.NewReader(testSPDXJSON), SBOMFormat("csv"))
+	assert.Error(t, err)
+}
+
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closingBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestProjectsExportSBOMs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects", testOrgID)).
+		MatchParam("types", "^npm,sast$").
+		Reply(200).
+		JSON(map[string]any{
+			"data": []map[string]any{
+				{"type": "project", "id": "project-1", "attributes": map[string]any{"type": "npm"}},
+				{"type": "project", "id": "project-2", "attributes": map[string]any{"type": "sast"}},
+			},
+		})
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects/project-1/sbom", testOrgID)).
+		Reply(200).
+		BodyString(testSPDXJSON)
+
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/projects/project-2/sbom", testOrgID)).
+		Reply(400).
+		JSON(map[string]any{"errors": []map[string]string{{"status": "400", "detail": "SBOMs are not supported for sast projects"}}})
+
+	client := NewClient("mock-token")
+	projects := ProjectsService{client: client, orgID: testOrgID}
+
+	files := map[string]*closingBuffer{}
+	err := projects.ExportSBOMs(context.Background(), SBOMFormatSPDX23JSON, ListProjectsOptions{Types: []string{"npm", "sast"}},
+		func(project Project) (io.WriteCloser, error) {
+			files[project.ID] = &closingBuffer{}
+			return files[project.ID], nil
+		})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 400, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "project-2")
+
+	assert.Equal(t, testSPDXJSON, files["project-1"].String())
+	assert.True(t, files["project-1"].closed)
+	assert.True(t, files["project-2"].closed)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,295 @@
+package snyk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	sbomTestAPIVersion = "2023-08-31~beta"
+
+	sbomTestPollInitialDelay = time.Second
+	sbomTestPollMaxDelay     = 30 * time.Second
+)
+
+// ErrSBOMTestFailed is returned when Snyk fails to test an SBOM
+var ErrSBOMTestFailed = errors.New("snyk: SBOM test failed")
+
+// SBOMTestResult is the result of testing an SBOM against Snyk's vulnerability database
+type SBOMTestResult struct {
+	JobID   string
+	Summary SBOMTestSummary
+	// The components listed in the SBOM which are affected by vulnerabilities
+	Components []SBOMTestComponent
+	// Every vulnerability found in the SBOM's components
+	Vulnerabilities []SBOMVulnerability
+}
+
+// SBOMTestSummary counts the issues found by an SBOM test
+type SBOMTestSummary struct {
+	TotalIssues             int
+	TotalVulnerablePackages int
+	TotalLicenseIssues      int
+	Vulnerabilities         SeverityCounts
+}
+
+// SBOMTestComponent is a component of a tested SBOM along with the vulnerabilities which affect it
+type SBOMTestComponent struct {
+	Name            string
+	Version         string
+	PURL            string
+	Vulnerabilities []SBOMVulnerability
+}
+
+// SBOMVulnerability is a vulnerability found by an SBOM test
+type SBOMVulnerability struct {
+	ID          string
+	Title       string
+	Description string
+	// The severity Snyk uses for the vulnerability, taking its sources into account
+	Severity Severity
+	// The severity reported by each source, such as Snyk or NVD
+	Severities []SBOMVulnerabilitySeverity
+	CVEs       []string
+	CWEs       []string
+}
+
+// SBOMVulnerabilitySeverity is the severity of a vulnerability according to a single source
+type SBOMVulnerabilitySeverity struct {
+	Source   string
+	Severity Severity
+	Score    float64
+	Vector   string
+}
+
+// TestSBOM tests the components of a CycloneDX or SPDX JSON document against Snyk's vulnerability database. The
+// document is uploaded, then the test job is polled with increasing delays until it finishes. Cancelling `ctx` stops
+// polling.
+func (o *Org) TestSBOM(ctx context.Context, sbom io.Reader) (SBOMTestResult, error) {
+	document, err := io.ReadAll(sbom)
+	if err != nil {
+		return SBOMTestResult{}, fmt.Errorf("Failed to read SBOM; %w", err)
+	}
+	if !json.Valid(document) {
+		return SBOMTestResult{}, fmt.Errorf("Failed to test SBOM; only JSON SBOMs can be tested")
+	}
+
+	jobID, err := o.createSBOMTest(ctx, document)
+	if err != nil {
+		return SBOMTestResult{}, fmt.Errorf("Failed to test SBOM; %w", err)
+	}
+
+	result, err := o.waitForSBOMTest(ctx, jobID)
+	if err != nil {
+		return SBOMTestResult{}, fmt.Errorf("Failed to test SBOM; %w", err)
+	}
+
+	return result, nil
+}
+
+func (o *Org) createSBOMTest(ctx context.Context, document []byte) (string, error) {
+	urlPath := fmt.Sprintf("/rest/orgs/%s/sbom_tests", o.ID)
+	params := url.Values{}
+	params.Set("version", sbomTestAPIVersion)
+
+	body := map[string]any{
+		"data": map[string]any{
+			"type":       "sbom_test",
+			"attributes": map[string]any{"sbom": json.RawMessage(document)},
+		},
+	}
+
+	resp, err := o.client.PostWithContext(ctx, urlPath, params, body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody := singleResourceResp{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	if err != nil {
+		return "", err
+	}
+
+	return respBody.Data.ID, nil
+}
+
+// waitForSBOMTest polls the status of the job until it has finished, then fetches its results
+func (o *Org) waitForSBOMTest(ctx context.Context, jobID string) (SBOMTestResult, error) {
+	urlPath := fmt.Sprintf("/rest/orgs/%s/sbom_tests/%s", o.ID, jobID)
+	params := url.Values{}
+	params.Set("version", sbomTestAPIVersion)
+
+	poll := newBackoff(o.client.clock, sbomTestPollInitialDelay, sbomTestPollMaxDelay)
+	for {
+		resp, err := o.client.GetWithContext(ctx, urlPath, params)
+		if err != nil {
+			return SBOMTestResult{}, err
+		}
+
+		// Finished jobs redirect to their results, which the HTTP client follows
+		if resp.Request != nil && strings.HasSuffix(resp.Request.URL.Path, "/results") {
+			return decodeSBOMTestResult(resp.Body, jobID)
+		}
+
+		respBody := struct {
+			Data struct {
+				Attributes struct {
+					Status string `json:"status"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&respBody)
+		resp.Body.Close()
+		if err != nil {
+			return SBOMTestResult{}, err
+		}
+
+		switch status := respBody.Data.Attributes.Status; status {
+		case "finished":
+			return o.getSBOMTestResult(ctx, jobID)
+		case "error":
+			return SBOMTestResult{}, fmt.Errorf("%w: job %s errored", ErrSBOMTestFailed, jobID)
+		}
+
+		if err := poll.wait(ctx); err != nil {
+			return SBOMTestResult{}, err
+		}
+	}
+}
+
+func (o *Org) getSBOMTestResult(ctx context.Context, jobID string) (SBOMTestResult, error) {
+	urlPath := fmt.Sprintf("/rest/orgs/%s/sbom_tests/%s/results", o.ID, jobID)
+	params := url.Values{}
+	params.Set("version", sbomTestAPIVersion)
+
+	resp, err := o.client.GetWithContext(ctx, urlPath, params)
+	if err != nil {
+		return SBOMTestResult{}, err
+	}
+	return decodeSBOMTestResult(resp.Body, jobID)
+}
+
+// sbomTestResultResp is the JSON:API document of an SBOM test's results. The affected packages and their
+// vulnerabilities are included resources.
+type sbomTestResultResp struct {
+	Data struct {
+		Attributes struct {
+			Summary struct {
+				TotalIssues             int            `json:"total_issues"`
+				TotalVulnerablePackages int            `json:"total_vulnerable_packages"`
+				TotalLicenseIssues      int            `json:"total_license_issues"`
+				Vulnerabilities         SeverityCounts `json:"vulnerabilities"`
+			} `json:"summary"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Included []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			// packages
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			PURL    string `json:"purl"`
+
+			// vulnerabilities
+			Title                  string `json:"title"`
+			Description            string `json:"description"`
+			EffectiveSeverityLevel string `json:"effective_severity_level"`
+			Severities             []struct {
+				Level  string  `json:"level"`
+				Score  float64 `json:"score"`
+				Vector string  `json:"vector"`
+				Source string  `json:"source"`
+			} `json:"severities"`
+			Identifiers struct {
+				CVE []string `json:"CVE"`
+				CWE []string `json:"CWE"`
+			} `json:"identifiers"`
+		} `json:"attributes"`
+		Relationships struct {
+			Vulnerabilities struct {
+				Data []Data `json:"data"`
+			} `json:"vulnerabilities"`
+		} `json:"relationships"`
+	} `json:"included"`
+}
+
+func decodeSBOMTestResult(body io.ReadCloser, jobID string) (SBOMTestResult, error) {
+	defer body.Close()
+
+	respBody := sbomTestResultResp{}
+	if err := json.NewDecoder(body).Decode(&respBody); err != nil {
+		return SBOMTestResult{}, err
+	}
+
+	summary := respBody.Data.Attributes.Summary
+	result := SBOMTestResult{
+		JobID: jobID,
+		Summary: SBOMTestSummary{
+			TotalIssues:             summary.TotalIssues,
+			TotalVulnerablePackages: summary.TotalVulnerablePackages,
+			TotalLicenseIssues:      summary.TotalLicenseIssues,
+			Vulnerabilities:         summary.Vulnerabilities,
+		},
+		Components:      []SBOMTestComponent{},
+		Vulnerabilities: []SBOMVulnerability{},
+	}
+
+	vulnerabilities := map[string]SBOMVulnerability{}
+	for _, included := range respBody.Included {
+		if included.Type != "vulnerabilities" {
+			continue
+		}
+
+		attributes := included.Attributes
+		vuln := SBOMVulnerability{
+			ID:          included.ID,
+			Title:       attributes.Title,
+			Description: attributes.Description,
+			Severity:    ParseSeverity(attributes.EffectiveSeverityLevel),
+			CVEs:        attributes.Identifiers.CVE,
+			CWEs:        attributes.Identifiers.CWE,
+		}
+		for _, severity := range attributes.Severities {
+			vuln.Severities = append(vuln.Severities, SBOMVulnerabilitySeverity{
+				Source:   severity.Source,
+				Severity: ParseSeverity(severity.Level),
+				Score:    severity.Score,
+				Vector:   severity.Vector,
+			})
+		}
+
+		vulnerabilities[vuln.ID] = vuln
+		result.Vulnerabilities = append(result.Vulnerabilities, vuln)
+	}
+
+	for _, included := range respBody.Included {
+		if included.Type != "packages" {
+			continue
+		}
+
+		component := SBOMTestComponent{
+			Name:    included.Attributes.Name,
+			Version: included.Attributes.Version,
+			PURL:    included.Attributes.PURL,
+		}
+		if component.PURL == "" {
+			component.PURL = included.ID
+		}
+		for _, ref := range included.Relationships.Vulnerabilities.Data {
+			if vuln, ok := vulnerabilities[ref.ID]; ok {
+				component.Vulnerabilities = append(component.Vulnerabilities, vuln)
+			}
+		}
+		result.Components = append(result.Components, component)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package snyk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/h2non/gock"
+)
+
+const testSBOMJobID = "b5d4ba8b-3b8c-4a4b-8b4a-6bd47d1c6c02"
+
+func mockSBOMTestJob(statuses ...string) {
+	gock.New(defaultBaseURL).
+		Post(fmt.Sprintf("/rest/orgs/%s/sbom_tests", testOrgID)).
+		MatchParam("version", sbomTestAPIVersion).
+		MatchType("application/vnd.api+json").
+		JSON(map[string]any{
+			"data": map[string]any{
+				"type":       "sbom_test",
+				"attributes": map[string]any{"sbom": map[string]any{"bomFormat": "CycloneDX"}},
+			},
+		}).
+		Reply(201).
+		JSON(map[string]any{"data": map[string]any{"type": "sbom_tests", "id": testSBOMJobID}})
+
+	for _, status := range statuses {
+		gock.New(defaultBaseURL).
+			Get(fmt.Sprintf("/rest/orgs/%s/sbom_tests/%s", testOrgID, testSBOMJobID)).
+			Reply(200).
+			JSON(map[string]any{
+				"data": map[string]any{
+					"type":       "sbom_tests",
+					"id":         testSBOMJobID,
+					"attributes": map[string]any{"status": status},
+				},
+			})
+	}
+}
+
+func TestOrgTestSBOM(t *testing.T) {
+	defer gock.Off()
+
+	respJSON, err := loadFixture("fixtures/sbom_test_results.json")
+	assert.NoError(t, err)
+
+	mockSBOMTestJob("processing", "processing", "finished")
+	gock.New(defaultBaseURL).
+		Get(fmt.Sprintf("/rest/orgs/%s/sbom_tests/%s/results", testOrgID, testSBOMJobID)).
+		Reply(200).
+		JSON(respJSON)
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+	org := Org{ID: testOrgID, client: client}
+
+	result, err := org.TestSBOM(context.Background(), strings.NewReader(`{"bomFormat": "CycloneDX"}`))
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.sleeps)
+
+	assert.Equal(t, testSBOMJobID, result.JobID)
+	assert.Equal(t, SBOMTestSummary{
+		TotalIssues:             2,
+		TotalVulnerablePackages: 1,
+		Vulnerabilities:         SeverityCounts{High: 1, Medium: 1},
+	}, result.Summary)
+
+	assert.Equal(t, 2, len(result.Vulnerabilities))
+	vuln := result.Vulnerabilities[0]
+	assert.Equal(t, "SNYK-JS-QS-3153490", vuln.ID)
+	assert.Equal(t, SeverityHigh, vuln.Severity)
+	assert.Equal(t, []string{"CVE-2022-24999"}, vuln.CVEs)
+	assert.Equal(t, SBOMVulnerabilitySeverity{
+		Source:   "NVD",
+		Severity: SeverityHigh,
+		Score:    7.5,
+		Vector:   "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+	}, vuln.Severities[1])
+
+	assert.Equal(t, 1, len(result.Components))
+	component := result.Components[0]
+	assert.Equal(t, "pkg:npm/qs@2.2.4", component.PURL)
+	assert.Equal(t, 2, len(component.Vulnerabilities))
+	assert.Equal(t, SeverityMedium, component.Vulnerabilities[1].Severity)
+}
+
+func TestOrgTestSBOMFailedJob(t *testing.T) {
+	defer gock.Off()
+
+	mockSBOMTestJob("processing", "error")
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("mock-token", WithClock(clock))
+	org := Org{ID: testOrgID, client: client}
+
+	_, err := org.TestSBOM(context.Background(), strings.NewReader(`{"bomFormat": "CycloneDX"}`))
+	assert.True(t, errors.Is(err, ErrSBOMTestFailed))
+	assert.True(t, gock.IsDone())
+}
+
+// cancellingClock cancels a context the first time it is slept on
+type cancellingClock struct {
+	fakeClock
+	cancel context.CancelFunc
+}
+
+func (c *cancellingClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.cancel()
+	return c.fakeClock.Sleep(ctx, d)
+}
+
+func TestOrgTestSBOMCancelled(t *testing.T) {
+	defer gock.Off()
+
+	mockSBOMTestJob("processing")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &cancellingClock{fakeClock: fakeClock{now: time.Now()}, cancel: cancel}
+	client := NewClient("mock-token", WithClock(clock))
+	org := Org{ID: testOrgID, client: client}
+
+	_, err := org.TestSBOM(ctx, strings.NewReader(`{"bomFormat": "CycloneDX"}`))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, gock.IsDone())
+}
+
+func TestOrgTestSBOMRejectsXML(t *testing.T) {
+	org := Org{ID: testOrgID, client: NewClient("mock-token")}
+
+	_, err := org.TestSBOM(context.Background(), strings.NewReader(`<bom></bom>`))
+	assert.Error(t, err)
+}
+
+func TestSeverity(t *testing.T) {
+	assert.Equal(t, SeverityCritical, ParseSeverity("CRITICAL"))
+	assert.True(t, SeverityHigh.AtLeast(SeverityMedium))
+	assert.True(t, SeverityHigh.AtLeast(SeverityHigh))
+	assert.False(t, SeverityLow.AtLeast(SeverityMedium))
+	assert.False(t, ParseSeverity("unknown").AtLeast(SeverityLow))
+
+	counts := SeverityCounts{}
+	for _, s := range []Severity{SeverityHigh, SeverityHigh, SeverityLow, "unknown"} {
+		counts.Add(s)
+	}
+	assert.Equal(t, SeverityCounts{High: 2, Low: 1}, counts)
+	assert.Equal(t, 3, counts.Total())
+}
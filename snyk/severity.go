@@ -0,0 +1,77 @@
+package snyk
+
+import "strings"
+
+// Severity is the severity level of an issue
+type Severity string
+
+// The severity levels used by Snyk, from least to most severe
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// ParseSeverity converts a severity level returned by the Snyk API into a Severity. Levels are matched regardless of
+// case, and unknown levels are returned as they are.
+func ParseSeverity(level string) Severity {
+	return Severity(strings.ToLower(level))
+}
+
+// rank orders severities, with unknown severities below SeverityLow
+func (s Severity) rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	}
+	return 0
+}
+
+// AtLeast reports whether the severity is as severe as `other` or more
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+// SeverityCounts is a number of issues by severity
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+}
+
+// Total returns the number of issues of any severity
+func (c SeverityCounts) Total() int {
+	return c.Critical + c.High + c.Medium + c.Low
+}
+
+// Sub returns the difference between the counts and `other` for each severity
+func (c SeverityCounts) Sub(other SeverityCounts) SeverityCounts {
+	return SeverityCounts{
+		Critical: c.Critical - other.Critical,
+		High:     c.High - other.High,
+		Medium:   c.Medium - other.Medium,
+		Low:      c.Low - other.Low,
+	}
+}
+
+// Add counts an issue of the given severity. Unknown severities aren't counted.
+func (c *SeverityCounts) Add(s Severity) {
+	switch s {
+	case SeverityCritical:
+		c.Critical++
+	case SeverityHigh:
+		c.High++
+	case SeverityMedium:
+		c.Medium++
+	case SeverityLow:
+		c.Low++
+	}
+}
@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -36,29 +37,36 @@ func (r *resource) intoTarget(client *Client, orgID string) Target {
 
 // GetAll gets all targets for the org
 func (s *TargetsService) GetAll() ([]Target, error) {
-	var targets []Target
+	return s.GetAllWithContext(context.Background())
+}
+
+// GetAllWithContext gets all targets for the org using the provided context
+func (s *TargetsService) GetAllWithContext(ctx context.Context) ([]Target, error) {
+	return collectPages(ctx, s.Pager(PageOptions{}))
+}
+
+// Pager returns a Pager which fetches all targets for the org one page at a time
+func (s *TargetsService) Pager(opts PageOptions) *Pager[Target] {
 	params := url.Values{}
 	params.Set("version", "2024-01-23~beta")
 	params.Set("excludeEmpty", "false")
 	path := fmt.Sprintf("/rest/orgs/%s/targets", s.orgID)
 
-	resources, err := getMultiResource(s.client, path, params)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, r := range resources {
-		targets = append(targets, r.intoTarget(s.client, s.orgID))
-	}
-
-	return targets, nil
+	return newPager(s.client, path, params, opts, func(r resource) Target {
+		return r.intoTarget(s.client, s.orgID)
+	})
 }
 
 // Get gets the target specified by the given `id`
 func (s *TargetsService) Get(id string) (Target, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext gets the target specified by the given `id` using the provided context
+func (s *TargetsService) GetWithContext(ctx context.Context, id string) (Target, error) {
 	var target Target
 	path := fmt.Sprintf("/rest/orgs/%s/targets/%s", s.orgID, id)
-	res, err := getSingleResource(s.client, path, nil)
+	res, err := getSingleResource(ctx, s.client, path, nil)
 	if err != nil {
 		return target, err
 	}
@@ -68,28 +76,31 @@ func (s *TargetsService) Get(id string) (Target, error) {
 
 // GetByRemoteURL gets the target specified by the given `remoteURL`
 func (s *TargetsService) GetByRemoteURL(remoteURL string) ([]Target, error) {
-	var targets []Target
+	return s.GetByRemoteURLWithContext(context.Background(), remoteURL)
+}
+
+// GetByRemoteURLWithContext gets the target specified by the given `remoteURL` using the provided context
+func (s *TargetsService) GetByRemoteURLWithContext(ctx context.Context, remoteURL string) ([]Target, error) {
 	path := fmt.Sprintf("/rest/orgs/%s/targets", s.orgID)
 	params := url.Values{}
 	params.Add("remoteUrl", remoteURL)
 
-	resources, err := getMultiResource(s.client, path, params)
-	if err != nil {
-		return targets, err
-	}
-
-	for _, r := range resources {
-		targets = append(targets, r.intoTarget(s.client, s.orgID))
-	}
-
-	return targets, nil
+	pager := newPager(s.client, path, params, PageOptions{}, func(r resource) Target {
+		return r.intoTarget(s.client, s.orgID)
+	})
+	return collectPages(ctx, pager)
 }
 
 // Delete deletes the target
 func (t *Target) Delete() error {
+	return t.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext deletes the target using the provided context
+func (t *Target) DeleteWithContext(ctx context.Context) error {
 	path := fmt.Sprintf("/rest/orgs/%s/targets/%s", t.orgID, t.ID)
 	params := url.Values{}
 	params.Add("version", "2024-01-23~beta")
-	_, err := t.client.Delete(path, params)
+	_, err := t.client.DeleteWithContext(ctx, path, params)
 	return err
 }
@@ -1,6 +1,7 @@
 package snyk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -21,11 +22,17 @@ type User struct {
 // UsersService handles requests for Project resources on the given Org
 type UsersService service
 
-// GetAll returns all user in the given group
+// GetAll returns all user in the given group. The v1 endpoint it uses is limited to 1 request per minute, so prefer
+// Group.Memberships when listing members repeatedly.
 func (s *UsersService) GetAll(groupID string) ([]User, error) {
+	return s.GetAllWithContext(context.Background(), groupID)
+}
+
+// GetAllWithContext returns all user in the given group using the provided context
+func (s *UsersService) GetAllWithContext(ctx context.Context, groupID string) ([]User, error) {
 	var users []User
 	urlPath := fmt.Sprintf("/v1/group/%s/members", groupID)
-	resp, err := s.client.Get(urlPath, nil)
+	resp, err := s.client.GetWithContext(ctx, urlPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,426 @@
+package snyktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	scrubbed = "[SCRUBBED]"
+	// The shortest Authorization value which is scrubbed from bodies
+	minSecretLength = 8
+)
+
+// RecorderMode sets whether a Recorder records new interactions or replays recorded ones
+type RecorderMode int
+
+const (
+	// ModeRecord sends requests to the real transport and records them
+	ModeRecord RecorderMode = iota
+	// ModeReplay answers requests from the cassette without sending them
+	ModeReplay
+)
+
+// MatchMode sets how a Recorder matches requests against recorded interactions
+type MatchMode int
+
+const (
+	// MatchStrict matches the method, path, query and body of requests. Each recorded interaction is replayed once.
+	MatchStrict MatchMode = iota
+	// MatchLoose matches the method, path and query of requests, ignoring the `version` query parameter and the body.
+	// Matching interactions are replayed once each in the order they were recorded, so that polling replays the
+	// recorded progress, and then the last of them is replayed any number of times.
+	MatchLoose
+)
+
+// ErrNoInteraction is returned when replaying a request which doesn't match any recorded interaction
+var ErrNoInteraction = errors.New("snyktest: no recorded interaction matches the request")
+
+// Cassette is the file format of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response received for it
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a Cassette
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  url.Values  `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response stored in a Cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an `http.RoundTripper` which records the interactions made by a snyk.Client into a cassette file, and
+// replays them later without network access. Pass it to the client with `snyk.WithTransport`.
+//
+// The Authorization header is always scrubbed from recorded requests, and its token is scrubbed wherever it appears in
+// recorded bodies. Other headers, query parameters and JSON body
+// fields can be scrubbed with the Recorder's options. Query parameters are compared regardless of their order, so
+// pages requested with the `version` and `starting_after` parameters rewritten by the client still match.
+type Recorder struct {
+	path      string
+	mode      RecorderMode
+	matchMode MatchMode
+	transport http.RoundTripper
+
+	scrubHeaders      []string
+	scrubQueryParams  []string
+	scrubBodyFields   []string
+	ignoreQueryParams []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// RecorderOption configures a Recorder when passed to NewRecorder
+type RecorderOption func(*Recorder)
+
+// WithMatchMode sets how requests are matched when replaying (default = MatchStrict)
+func WithMatchMode(mode MatchMode) RecorderOption {
+	return func(r *Recorder) {
+		r.matchMode = mode
+	}
+}
+
+// WithRealTransport sets the transport used to send requests when recording (default = `http.DefaultTransport`)
+func WithRealTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubbedHeaders scrubs the given request and response headers from the cassette
+func WithScrubbedHeaders(headers ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubHeaders = append(r.scrubHeaders, headers...)
+	}
+}
+
+// WithScrubbedQueryParams scrubs the given query parameters from recorded requests
+func WithScrubbedQueryParams(params ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubQueryParams = append(r.scrubQueryParams, params...)
+	}
+}
+
+// WithScrubbedBodyFields scrubs the values of JSON object fields with the given names, at any depth, from recorded
+// request and response bodies
+func WithScrubbedBodyFields(fields ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubBodyFields = append(r.scrubBodyFields, fields...)
+	}
+}
+
+// WithIgnoredQueryParams ignores the given query parameters when matching requests in MatchLoose mode, in addition to
+// `version`
+func WithIgnoredQueryParams(params ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.ignoreQueryParams = append(r.ignoreQueryParams, params...)
+	}
+}
+
+// NewRecorder creates a Recorder for the cassette file at `path`. In ModeReplay, the cassette is loaded from the
+// file. In ModeRecord, the cassette is written to the file by Save.
+func NewRecorder(path string, mode RecorderMode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:              path,
+		mode:              mode,
+		transport:         http.DefaultTransport,
+		scrubHeaders:      []string{"Authorization"},
+		ignoreQueryParams: []string{"version"},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(contents, &r.cassette); err != nil {
+			return nil, fmt.Errorf("snyktest: failed to parse cassette %s; %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Save writes the recorded interactions to the cassette file
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contents, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, contents, 0o644)
+}
+
+// RoundTrip implements `http.RoundTripper`
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, outReq, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := authSecrets(req.Header.Get("Authorization"))
+	recorded := r.recordRequest(req, body, secrets)
+
+	if r.mode == ModeReplay {
+		if outReq.Body != nil {
+			outReq.Body.Close()
+		}
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.transport.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrubHeader(resp.Header),
+			Body:       r.scrubBody(respBody, secrets),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// readRequestBody reads the body of the request without modifying it, as `http.RoundTripper` requires. The body is
+// read from a copy made by `GetBody` if the request has one. Otherwise, the body is consumed and the returned request
+// is a clone which sends a copy of it.
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	if req.GetBody != nil {
+		bodyCopy, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer bodyCopy.Close()
+
+		body, err := io.ReadAll(bodyCopy)
+		if err != nil {
+			return nil, nil, err
+		}
+		return body, req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, clone, nil
+}
+
+// authSecrets returns the values of the Authorization header which must not be written to a cassette: the whole
+// header, and the credential after its scheme, e.g. the token in `token <token>`. Values shorter than
+// minSecretLength aren't returned, so that scrubbing them doesn't mangle unrelated text.
+func authSecrets(auth string) []string {
+	var secrets []string
+	for _, secret := range []string{auth, auth[strings.LastIndex(auth, " ")+1:]} {
+		if len(secret) >= minSecretLength && !contains(secrets, secret) {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match, lastMatch := -1, -1
+	for i, interaction := range r.cassette.Interactions {
+		if !r.matches(interaction.Request, recorded) {
+			continue
+		}
+		lastMatch = i
+		if !r.used[i] {
+			match = i
+			break
+		}
+	}
+	if match == -1 && r.matchMode == MatchLoose {
+		match = lastMatch
+	}
+
+	if match != -1 {
+		r.used[match] = true
+		response := r.cassette.Interactions[match].Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+			StatusCode:    response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(response.Body))),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s?%s", ErrNoInteraction, recorded.Method, recorded.Path, recorded.Query.Encode())
+}
+
+func (r *Recorder) matches(recorded, req RecordedRequest) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path {
+		return false
+	}
+
+	if r.matchMode == MatchStrict {
+		return recorded.Query.Encode() == req.Query.Encode() && canonicalJSON(recorded.Body) == canonicalJSON(req.Body)
+	}
+
+	return r.looseQuery(recorded.Query).Encode() == r.looseQuery(req.Query).Encode()
+}
+
+// looseQuery returns the query without the parameters ignored in MatchLoose mode
+func (r *Recorder) looseQuery(query url.Values) url.Values {
+	loose := url.Values{}
+	for k, v := range query {
+		if !contains(r.ignoreQueryParams, k) {
+			loose[k] = v
+		}
+	}
+	return loose
+}
+
+// recordRequest converts the request and its body into their scrubbed, recorded form
+func (r *Recorder) recordRequest(req *http.Request, body []byte, secrets []string) RecordedRequest {
+	query := req.URL.Query()
+	for _, param := range r.scrubQueryParams {
+		if query.Has(param) {
+			query.Set(param, scrubbed)
+		}
+	}
+
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query,
+		Header: r.scrubHeader(req.Header),
+		Body:   r.scrubBody(body, secrets),
+	}
+}
+
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range r.scrubHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, scrubbed)
+		}
+	}
+	return header
+}
+
+// scrubBody replaces the literal `secrets` anywhere in the body, then the values of the configured JSON fields
+func (r *Recorder) scrubBody(body []byte, secrets []string) string {
+	for _, secret := range secrets {
+		body = bytes.ReplaceAll(body, []byte(secret), []byte(scrubbed))
+	}
+
+	if len(r.scrubBodyFields) == 0 || len(body) == 0 {
+		return string(body)
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	scrubbedBody, err := json.Marshal(r.scrubValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(scrubbedBody)
+}
+
+func (r *Recorder) scrubValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, fieldValue := range v {
+			if contains(r.scrubBodyFields, k) {
+				v[k] = scrubbed
+			} else {
+				v[k] = r.scrubValue(fieldValue)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.scrubValue(item)
+		}
+	}
+	return value
+}
+
+// canonicalJSON returns the body with consistent formatting and key order if it is JSON
+func canonicalJSON(body string) string {
+	var value any
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return string(canonical)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package snyktest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	server := NewServer()
+	org := server.AddOrg(Org{Name: "org1"})
+	for i := 0; i < 15; i++ {
+		server.AddProject(org.ID, Project{Name: "project", Type: "npm"})
+	}
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorder(cassettePath, ModeRecord, WithScrubbedBodyFields("slug"))
+	assert.NoError(t, err)
+
+	client := snyk.NewClient("token secret-token", snyk.WithBaseURL(server.URL), snyk.WithTransport(recorder))
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	recorded, err := snykOrg.Projects.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 15, len(recorded))
+	assert.NoError(t, recorder.Save())
+
+	// The server is no longer needed to replay the interactions
+	server.Close()
+
+	contents, err := os.ReadFile(cassettePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(contents), "secret-token")
+
+	replayer, err := NewRecorder(cassettePath, ModeReplay)
+	assert.NoError(t, err)
+
+	client = snyk.NewClient("token other-token", snyk.WithBaseURL(server.URL), snyk.WithTransport(replayer), snyk.WithMaxRetries(0))
+	snykOrg, err = client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "org1", snykOrg.Name)
+	assert.Equal(t, "[SCRUBBED]", snykOrg.Slug)
+	replayed, err := snykOrg.Projects.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, recorded[14].ID, replayed[14].ID)
+
+	// In strict mode, each interaction is only replayed once
+	_, err = client.Orgs.Get(org.ID)
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestRecorderLooseMatching(t *testing.T) {
+	server := NewServer()
+	org := server.AddOrg(Org{Name: "org1"})
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(cassettePath, ModeRecord)
+	assert.NoError(t, err)
+
+	client := server.Client(snyk.WithTransport(recorder))
+	_, err = client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+	server.Close()
+
+	replayer, err := NewRecorder(cassettePath, ModeReplay, WithMatchMode(MatchLoose))
+	assert.NoError(t, err)
+
+	client = snyk.NewClient("token", snyk.WithBaseURL(server.URL), snyk.WithTransport(replayer), snyk.WithAPIVersion("2030-01-01"), snyk.WithMaxRetries(0))
+	for i := 0; i < 2; i++ {
+		snykOrg, err := client.Orgs.Get(org.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "org1", snykOrg.Name)
+	}
+
+	_, err = client.Orgs.GetAll()
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+// instantClock is a snyk.Clock whose sleeps return immediately
+type instantClock struct{}
+
+func (instantClock) Now() time.Time {
+	return time.Now()
+}
+
+func (instantClock) Sleep(ctx context.Context, _ time.Duration) error {
+	return ctx.Err()
+}
+
+func TestRecorderLooseMatchingReplaysPolling(t *testing.T) {
+	const (
+		orgID   = "3f8a2b1c-4d5e-4f60-8a7b-9c0d1e2f3a4b"
+		jobPath = "/v1/org/" + orgID + "/integrations/integration-1/import/job-1"
+	)
+
+	// The import job is pending for the first two polls
+	polls := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/orgs/"+orgID:
+			_, _ = w.Write([]byte(`{"data":{"type":"org","id":"` + orgID + `","attributes":{"name":"org1"}}}`))
+		case r.Method == http.MethodPost:
+			w.Header().Set("Location", server.URL+jobPath)
+			w.WriteHeader(http.StatusCreated)
+		case polls < 2:
+			polls++
+			_, _ = w.Write([]byte(`{"id":"job-1","status":"pending"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"job-1","status":"complete"}`))
+		}
+	}))
+
+	importAndWait := func(client *snyk.Client) snyk.ImportResult {
+		org, err := client.Orgs.Get(orgID)
+		assert.NoError(t, err)
+		job, err := org.ImportProject("integration-1", snyk.ImportTarget{})
+		assert.NoError(t, err)
+		// Replaying the pending poll forever would otherwise never return
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		result, err := job.Wait(ctx)
+		assert.NoError(t, err)
+		return result
+	}
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(cassettePath, ModeRecord)
+	assert.NoError(t, err)
+
+	client := snyk.NewClient("token", snyk.WithBaseURL(server.URL), snyk.WithTransport(recorder), snyk.WithClock(instantClock{}))
+	assert.Equal(t, snyk.ImportComplete, importAndWait(client).Status)
+	assert.NoError(t, recorder.Save())
+	server.Close()
+
+	replayer, err := NewRecorder(cassettePath, ModeReplay, WithMatchMode(MatchLoose))
+	assert.NoError(t, err)
+
+	// The pending polls are replayed before the completed one, which is then replayed for any later polls
+	client = snyk.NewClient("token", snyk.WithBaseURL(server.URL), snyk.WithTransport(replayer), snyk.WithClock(instantClock{}), snyk.WithMaxRetries(0))
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, snyk.ImportComplete, importAndWait(client).Status)
+	}
+}
+
+func TestRecorderDoesNotModifyRequests(t *testing.T) {
+	const token = "token 0f6c1a8e-3b7d-4c52-9a4e-8d2f5b6c7e10"
+
+	// The server echoes the request body and the token, as some error responses do
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"received":` + string(body) + `,"auth":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(cassettePath, ModeRecord)
+	assert.NoError(t, err)
+
+	for _, getBody := range []bool{true, false} {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/v1/org", bytes.NewReader([]byte(`{"name":"org1"}`)))
+		assert.NoError(t, err)
+		assert.True(t, req.GetBody != nil)
+		if !getBody {
+			req.GetBody = nil
+		}
+		req.Header.Set("Authorization", token)
+		originalBody := req.Body
+
+		resp, err := recorder.RoundTrip(req)
+		assert.NoError(t, err)
+		respBody, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(respBody), `"received":{"name":"org1"}`)
+		assert.True(t, req.Body == originalBody)
+	}
+	assert.NoError(t, recorder.Save())
+
+	contents, err := os.ReadFile(cassettePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(contents), "0f6c1a8e-3b7d-4c52-9a4e-8d2f5b6c7e10")
+	assert.Contains(t, string(contents), "[SCRUBBED]")
+}
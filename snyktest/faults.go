@@ -0,0 +1,224 @@
+package snyktest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault is an injected failure. It is given the request and the transport that would otherwise have sent it, so faults
+// can either answer the request themselves or alter the real response.
+type Fault func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// FaultRule selects the requests which a Fault is injected into
+type FaultRule struct {
+	// Method is the HTTP method to match. If empty, any method matches.
+	Method string
+	// Path is a `path.Match` pattern for the request path, e.g. `/rest/orgs/*/projects`. If empty, any path matches.
+	Path string
+	// Call injects the fault only into the Nth (starting at 1) request matching the rule. If 0, every matching request
+	// is considered.
+	Call int
+	// Times is the number of times the fault is injected. If 0, it is injected into every selected request.
+	Times int
+	// Fault is the failure to inject
+	Fault Fault
+}
+
+// FaultTransport is an `http.RoundTripper` which injects scripted failures into the requests made by a snyk.Client,
+// such as rate limiting, server errors, truncated bodies, slow responses and connection resets. Requests which no rule
+// applies to are sent with the underlying transport. Pass it to the client with `snyk.WithTransport`.
+//
+//	faults := snyktest.NewFaultTransport(nil)
+//	faults.Inject(snyktest.FaultRule{Path: "/rest/orgs/*/projects", Call: 2, Fault: snyktest.ServerError(503)})
+//	client := server.Client(snyk.WithTransport(faults))
+type FaultTransport struct {
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	rules    []*faultRuleState
+	calls    int
+	injected int
+}
+
+type faultRuleState struct {
+	FaultRule
+	matched  int
+	injected int
+}
+
+// NewFaultTransport creates a FaultTransport which sends requests with `transport`, or `http.DefaultTransport` if it
+// is nil
+func NewFaultTransport(transport http.RoundTripper) *FaultTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &FaultTransport{transport: transport}
+}
+
+// Inject adds a rule to the transport. Rules are checked in the order they were added, and at most one fault is
+// injected into each request.
+func (f *FaultTransport) Inject(rule FaultRule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rules = append(f.rules, &faultRuleState{FaultRule: rule})
+}
+
+// Calls returns the number of requests the transport has received, including those a fault was injected into
+func (f *FaultTransport) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+// Injected returns the number of requests a fault was injected into
+func (f *FaultTransport) Injected() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.injected
+}
+
+// RoundTrip implements `http.RoundTripper`
+func (f *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := f.selectFault(req)
+	if fault == nil {
+		return f.transport.RoundTrip(req)
+	}
+	return fault(req, f.transport)
+}
+
+func (f *FaultTransport) selectFault(req *http.Request) Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	var fault Fault
+	for _, rule := range f.rules {
+		if !rule.matches(req) {
+			continue
+		}
+		// Every matching rule counts the request so that `Call` is independent of other rules
+		rule.matched++
+
+		if fault != nil || (rule.Call != 0 && rule.matched != rule.Call) || (rule.Times != 0 && rule.injected >= rule.Times) {
+			continue
+		}
+		rule.injected++
+		fault = rule.Fault
+	}
+
+	if fault != nil {
+		f.injected++
+	}
+	return fault
+}
+
+func (r *faultRuleState) matches(req *http.Request) bool {
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+	if r.Path != "" {
+		if ok, _ := path.Match(r.Path, req.URL.Path); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// RateLimited responds with 429 Too Many Requests and a Retry-After header of `retryAfter`, rounded up to whole seconds
+func RateLimited(retryAfter time.Duration) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		seconds := int((retryAfter + time.Second - 1) / time.Second)
+		resp := faultResponse(req, http.StatusTooManyRequests, "Too many requests, please try again later")
+		resp.Header.Set("Retry-After", strconv.Itoa(seconds))
+		return resp, nil
+	}
+}
+
+// ServerError responds with the given status code, such as 500, 502 or 503, and a JSON:API error body
+func ServerError(statusCode int) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		return faultResponse(req, statusCode, http.StatusText(statusCode)), nil
+	}
+}
+
+// TruncatedBody sends the request, then cuts the response body to half its length, leaving invalid JSON
+func TruncatedBody() Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		body = body[:len(body)/2]
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		return resp, nil
+	}
+}
+
+// SlowResponse waits for `delay` before sending the request. The wait ends early with the context's error if the
+// request's context is done first, as it would for a client timeout.
+func SlowResponse(delay time.Duration) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+			return next.RoundTrip(req)
+		}
+	}
+}
+
+// ConnectionReset fails the request with a "connection reset by peer" network error without sending it
+func ConnectionReset() Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		return nil, &net.OpError{
+			Op:  "read",
+			Net: "tcp",
+			Err: os.NewSyscallError("read", syscall.ECONNRESET),
+		}
+	}
+}
+
+// faultResponse builds an error response in the JSON:API format used by the REST API
+func faultResponse(req *http.Request, statusCode int, detail string) *http.Response {
+	body := fmt.Sprintf(
+		`{"jsonapi":{"version":"1.0"},"errors":[{"status":"%d","title":%q,"detail":%q}]}`,
+		statusCode, http.StatusText(statusCode), detail,
+	)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/vnd.api+json"}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,41 @@
+package snyktest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFaultTransportSelectsRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	faults := NewFaultTransport(nil)
+	faults.Inject(FaultRule{Method: http.MethodGet, Path: "/rest/groups", Call: 2, Fault: ServerError(http.StatusInternalServerError)})
+	faults.Inject(FaultRule{Path: "/rest/orgs", Times: 1, Fault: RateLimited(1500 * time.Millisecond)})
+
+	httpClient := &http.Client{Transport: faults}
+	get := func(path string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path+"?version=2023-09-14~beta", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "token snyktest")
+		resp, err := httpClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	assert.Equal(t, http.StatusOK, get("/rest/groups").StatusCode)
+	assert.Equal(t, http.StatusInternalServerError, get("/rest/groups").StatusCode)
+	assert.Equal(t, http.StatusOK, get("/rest/groups").StatusCode)
+
+	resp := get("/rest/orgs")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, get("/rest/orgs").StatusCode)
+
+	assert.Equal(t, 5, faults.Calls())
+	assert.Equal(t, 2, faults.Injected())
+}
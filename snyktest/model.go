@@ -0,0 +1,412 @@
+package snyktest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+)
+
+// Group is a Snyk Group stored by the fake server
+type Group struct {
+	ID   string
+	Name string
+}
+
+// Org is a Snyk Organization stored by the fake server
+type Org struct {
+	ID      string
+	Name    string
+	Slug    string
+	GroupID string
+}
+
+// Membership is a user's membership of a group or org stored by the fake server
+type Membership struct {
+	ID       string
+	UserID   string
+	Name     string
+	Username string
+	Email    string
+	// The name of the user's role, e.g. `Group Admin` or `Org Collaborator`
+	Role   string
+	RoleID string
+}
+
+// Target is a scan target stored by the fake server
+type Target struct {
+	ID          string
+	DisplayName string
+	Origin      string
+	RemoteURL   string
+	IsPrivate   bool
+}
+
+// Project is a Snyk Project stored by the fake server
+type Project struct {
+	ID                  string
+	Name                string
+	Type                string
+	TargetID            string
+	TargetFile          string
+	TargetReference     string
+	Origin              string
+	Created             time.Time
+	Status              string
+	BusinessCriticality []string
+	Environment         []string
+	Lifecycle           []string
+	Tags                []snyk.Tag
+	ReadOnly            bool
+	OwnerID             string
+}
+
+// ContainerImage is a scanned container image stored by the fake server
+type ContainerImage struct {
+	ID       string
+	Layers   []string
+	Names    []string
+	Platform string
+}
+
+// IssueV2 is an issue returned by the REST issues endpoint of the fake server
+type IssueV2 struct {
+	ID                     string
+	Key                    string
+	Title                  string
+	Type                   string
+	Status                 string
+	EffectiveSeverityLevel string
+	Ignored                bool
+	ProjectID              string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// orgState holds everything stored for a single org
+type orgState struct {
+	org      Org
+	members  []Membership
+	targets  []Target
+	projects []*Project
+	images   []ContainerImage
+	issues   []*IssueV2
+	// v1 aggregated issues, ignores, settings, dep graphs and snapshots by project ID
+	v1Issues  map[string][]snyk.Issue
+	ignores   map[string]map[string][]snyk.Ignore
+	settings  map[string]snyk.ProjectSettings
+	depGraphs map[string]snyk.DepGraph
+	snapshots map[string][]snyk.ProjectSnapshot
+	// SBOM documents by project ID, then format
+	sboms map[string]map[snyk.SBOMFormat]string
+}
+
+func newOrgState(org Org) *orgState {
+	return &orgState{
+		org:       org,
+		v1Issues:  map[string][]snyk.Issue{},
+		ignores:   map[string]map[string][]snyk.Ignore{},
+		settings:  map[string]snyk.ProjectSettings{},
+		depGraphs: map[string]snyk.DepGraph{},
+		snapshots: map[string][]snyk.ProjectSnapshot{},
+		sboms:     map[string]map[snyk.SBOMFormat]string{},
+	}
+}
+
+// AddGroup stores the group, generating an ID if it doesn't have one
+func (s *Server) AddGroup(group Group) Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if group.ID == "" {
+		group.ID = newID()
+	}
+	s.groups = append(s.groups, group)
+	return group
+}
+
+// AddOrg stores the org, generating an ID and slug if it doesn't have them
+func (s *Server) AddOrg(org Org) Org {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if org.ID == "" {
+		org.ID = newID()
+	}
+	if org.Slug == "" {
+		org.Slug = org.ID
+	}
+	s.orgs = append(s.orgs, newOrgState(org))
+	return org
+}
+
+// AddGroupMember stores the membership of the group, generating a membership and user ID if it doesn't have them
+func (s *Server) AddGroupMember(groupID string, member Membership) Membership {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasGroup(groupID) {
+		panic(fmt.Sprintf("snyktest: group %s does not exist", groupID))
+	}
+	member = newMembership(member)
+	s.groupMembers[groupID] = append(s.groupMembers[groupID], member)
+	return member
+}
+
+// AddOrgMember stores the membership of the org, generating a membership and user ID if it doesn't have them
+func (s *Server) AddOrgMember(orgID string, member Membership) Membership {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := s.mustOrg(orgID)
+	member = newMembership(member)
+	org.members = append(org.members, member)
+	return member
+}
+
+func newMembership(member Membership) Membership {
+	if member.ID == "" {
+		member.ID = newID()
+	}
+	if member.UserID == "" {
+		member.UserID = newID()
+	}
+	return member
+}
+
+// AddTarget stores the target in the org, generating an ID if it doesn't have one
+func (s *Server) AddTarget(orgID string, target Target) Target {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if target.ID == "" {
+		target.ID = newID()
+	}
+	org := s.mustOrg(orgID)
+	org.targets = append(org.targets, target)
+	return target
+}
+
+// AddProject stores the project in the org, generating an ID if it doesn't have one
+func (s *Server) AddProject(orgID string, project Project) Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if project.ID == "" {
+		project.ID = newID()
+	}
+	if project.Created.IsZero() {
+		project.Created = time.Now().UTC().Truncate(time.Second)
+	}
+	if project.Status == "" {
+		project.Status = "active"
+	}
+	org := s.mustOrg(orgID)
+	org.projects = append(org.projects, &project)
+	return project
+}
+
+// AddContainerImage stores the container image in the org
+func (s *Server) AddContainerImage(orgID string, image ContainerImage) ContainerImage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if image.ID == "" {
+		image.ID = "sha256:" + newID()
+	}
+	org := s.mustOrg(orgID)
+	org.images = append(org.images, image)
+	return image
+}
+
+// AddIssue stores a v1 aggregated issue on the project
+func (s *Server) AddIssue(orgID, projectID string, issue snyk.Issue) snyk.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if issue.ID == "" {
+		issue.ID = newID()
+	}
+	org := s.mustOrg(orgID)
+	org.v1Issues[projectID] = append(org.v1Issues[projectID], issue)
+	return issue
+}
+
+// AddIssueV2 stores an issue returned by the REST issues endpoint of the org
+func (s *Server) AddIssueV2(orgID string, issue IssueV2) IssueV2 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if issue.ID == "" {
+		issue.ID = newID()
+	}
+	if issue.Status == "" {
+		issue.Status = "open"
+	}
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = time.Now().UTC().Truncate(time.Second)
+		issue.UpdatedAt = issue.CreatedAt
+	}
+	org := s.mustOrg(orgID)
+	org.issues = append(org.issues, &issue)
+	return issue
+}
+
+// SetDepGraph stores the dependency graph of the project
+func (s *Server) SetDepGraph(orgID, projectID string, graph snyk.DepGraph) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := s.mustOrg(orgID)
+	org.depGraphs[projectID] = graph
+}
+
+// AddSnapshot stores a snapshot in the history of the project, generating an ID and creation time if it doesn't have
+// them
+func (s *Server) AddSnapshot(orgID, projectID string, snapshot snyk.ProjectSnapshot) snyk.ProjectSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if snapshot.ID == "" {
+		snapshot.ID = newID()
+	}
+	if snapshot.Created.IsZero() {
+		snapshot.Created = time.Now().UTC().Truncate(time.Second)
+	}
+	org := s.mustOrg(orgID)
+	org.snapshots[projectID] = append(org.snapshots[projectID], snapshot)
+	return snapshot
+}
+
+// SetSBOM stores the SBOM document returned for the project in the given format
+func (s *Server) SetSBOM(orgID, projectID string, format snyk.SBOMFormat, document string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := s.mustOrg(orgID)
+	if org.sboms[projectID] == nil {
+		org.sboms[projectID] = map[snyk.SBOMFormat]string{}
+	}
+	org.sboms[projectID][format] = document
+}
+
+// Project returns the project with the given ID and the ID of the org it is in
+func (s *Server) Project(projectID string) (Project, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, org := range s.orgs {
+		if project, _ := org.project(projectID); project != nil {
+			return *project, org.org.ID, true
+		}
+	}
+	return Project{}, "", false
+}
+
+// Orgs returns the stored orgs
+func (s *Server) Orgs() []Org {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var orgs []Org
+	for _, org := range s.orgs {
+		orgs = append(orgs, org.org)
+	}
+	return orgs
+}
+
+// Projects returns the projects in the org
+func (s *Server) Projects(orgID string) []Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var projects []Project
+	if org := s.org(orgID); org != nil {
+		for _, p := range org.projects {
+			projects = append(projects, *p)
+		}
+	}
+	return projects
+}
+
+// GroupMembers returns the memberships of the group
+func (s *Server) GroupMembers(groupID string) []Membership {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Membership(nil), s.groupMembers[groupID]...)
+}
+
+// OrgMembers returns the memberships of the org
+func (s *Server) OrgMembers(orgID string) []Membership {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if org := s.org(orgID); org != nil {
+		return append([]Membership(nil), org.members...)
+	}
+	return nil
+}
+
+// Targets returns the targets in the org
+func (s *Server) Targets(orgID string) []Target {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if org := s.org(orgID); org != nil {
+		return append([]Target(nil), org.targets...)
+	}
+	return nil
+}
+
+// Ignores returns the ignores on the project by issue ID
+func (s *Server) Ignores(orgID, projectID string) map[string][]snyk.Ignore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ignores := map[string][]snyk.Ignore{}
+	if org := s.org(orgID); org != nil {
+		for issueID, issueIgnores := range org.ignores[projectID] {
+			ignores[issueID] = append([]snyk.Ignore(nil), issueIgnores...)
+		}
+	}
+	return ignores
+}
+
+// org returns the state of the org with the given ID. The caller must hold `s.mu`.
+func (s *Server) org(orgID string) *orgState {
+	for _, org := range s.orgs {
+		if org.org.ID == orgID {
+			return org
+		}
+	}
+	return nil
+}
+
+// mustOrg returns the state of the org with the given ID, panicking if it doesn't exist. The caller must hold `s.mu`.
+func (s *Server) mustOrg(orgID string) *orgState {
+	org := s.org(orgID)
+	if org == nil {
+		panic(fmt.Sprintf("snyktest: org %s does not exist", orgID))
+	}
+	return org
+}
+
+func (o *orgState) project(projectID string) (*Project, int) {
+	for i, p := range o.projects {
+		if p.ID == projectID {
+			return p, i
+		}
+	}
+	return nil, -1
+}
+
+// newID returns a random UUID
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
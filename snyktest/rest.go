@@ -0,0 +1,526 @@
+package snyktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+)
+
+// serveREST handles requests to the REST API. `segments` is the request path without the leading `rest`.
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request, segments []string) {
+	query := r.URL.Query()
+
+	if _, ok := match(segments, "groups"); ok && r.Method == http.MethodGet {
+		var resources []resource
+		for _, g := range s.groups {
+			resources = append(resources, groupResource(g))
+		}
+		writePage(w, r, resources)
+		return
+	}
+
+	if params, ok := match(segments, "groups", "*"); ok && r.Method == http.MethodGet {
+		for _, g := range s.groups {
+			if g.ID == params[0] {
+				writeSingle(w, groupResource(g))
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Group %s was not found", params[0]))
+		return
+	}
+
+	if params, ok := match(segments, "groups", "*", "orgs"); ok && r.Method == http.MethodGet {
+		var resources []resource
+		for _, o := range s.orgs {
+			if o.org.GroupID == params[0] && orgMatches(o.org, query) {
+				resources = append(resources, orgResource(o.org))
+			}
+		}
+		writePage(w, r, resources)
+		return
+	}
+
+	if params, ok := match(segments, "groups", "*", "memberships"); ok && r.Method == http.MethodGet {
+		if !s.hasGroup(params[0]) {
+			writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Group %s was not found", params[0]))
+			return
+		}
+		var resources []resource
+		for _, m := range s.groupMembers[params[0]] {
+			resources = append(resources, membershipResource("group_membership", "group_role", m))
+		}
+		writePage(w, r, resources)
+		return
+	}
+
+	if params, ok := match(segments, "groups", "*", "memberships", "*"); ok && r.Method == http.MethodDelete {
+		s.deleteGroupMembership(w, params[0], params[1], query.Get("cascade") == "true")
+		return
+	}
+
+	if _, ok := match(segments, "orgs"); ok && r.Method == http.MethodGet {
+		var resources []resource
+		for _, o := range s.orgs {
+			if !orgMatches(o.org, query) {
+				continue
+			}
+			resources = append(resources, orgResource(o.org))
+		}
+		writePage(w, r, resources)
+		return
+	}
+
+	if len(segments) < 2 || segments[0] != "orgs" {
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s is not a Snyk API path", r.URL.Path))
+		return
+	}
+
+	org := s.org(segments[1])
+	if org == nil {
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Org %s was not found", segments[1]))
+		return
+	}
+	segments = segments[2:]
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeSingle(w, orgResource(org.org))
+
+	case len(segments) == 0 && r.Method == http.MethodPatch:
+		body := struct {
+			Data struct {
+				Attributes struct {
+					Name string `json:"name"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The request body is not valid JSON")
+			return
+		}
+		if body.Data.Attributes.Name != "" {
+			org.org.Name = body.Data.Attributes.Name
+		}
+		writeSingle(w, orgResource(org.org))
+
+	case isPath(segments, "memberships") && r.Method == http.MethodGet:
+		var resources []resource
+		for _, m := range org.members {
+			if userID := query.Get("user_id"); userID != "" && m.UserID != userID {
+				continue
+			}
+			resources = append(resources, membershipResource("org_membership", "org_role", m))
+		}
+		writePage(w, r, resources)
+
+	case isPath(segments, "memberships", "*") && r.Method == http.MethodDelete:
+		for i, m := range org.members {
+			if m.ID == segments[1] {
+				org.members = append(org.members[:i], org.members[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Membership %s was not found", segments[1]))
+
+	case isPath(segments, "targets") && r.Method == http.MethodGet:
+		var resources []resource
+		for _, t := range org.targets {
+			if remoteURL := query.Get("remoteUrl"); remoteURL != "" && t.RemoteURL != remoteURL {
+				continue
+			}
+			resources = append(resources, targetResource(t))
+		}
+		writePage(w, r, resources)
+
+	case isPath(segments, "targets", "*"):
+		for i, t := range org.targets {
+			if t.ID != segments[1] {
+				continue
+			}
+			switch r.Method {
+			case http.MethodGet:
+				writeSingle(w, targetResource(t))
+			case http.MethodDelete:
+				org.targets = append(org.targets[:i], org.targets[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", r.Method)
+			}
+			return
+		}
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Target %s was not found", segments[1]))
+
+	case isPath(segments, "projects") && r.Method == http.MethodGet:
+		var resources []resource
+		for _, p := range org.projects {
+			if !projectMatches(p, query) {
+				continue
+			}
+			res := org.projectResource(p)
+			if query.Get("expand") == "target" {
+				org.expandTarget(res)
+			}
+			resources = append(resources, res)
+		}
+		writePage(w, r, resources)
+
+	case isPath(segments, "projects", "*"):
+		project, _ := org.project(segments[1])
+		if project == nil {
+			writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Project %s was not found", segments[1]))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeSingle(w, org.projectResource(project))
+		case http.MethodPatch:
+			updateProject(w, r, org, project)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", r.Method)
+		}
+
+	case isPath(segments, "projects", "*", "sbom") && r.Method == http.MethodGet:
+		project, _ := org.project(segments[1])
+		if project == nil {
+			writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Project %s was not found", segments[1]))
+			return
+		}
+		document, ok := org.sboms[project.ID][snyk.SBOMFormat(query.Get("format"))]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("No SBOM in format %q for project %s", query.Get("format"), project.ID))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(document))
+
+	case isPath(segments, "container_images") && r.Method == http.MethodGet:
+		var resources []resource
+		for _, image := range org.images {
+			resources = append(resources, containerImageResource(image))
+		}
+		writePage(w, r, resources)
+
+	case isPath(segments, "issues") && r.Method == http.MethodGet:
+		var resources []resource
+		for _, issue := range org.issues {
+			if projectID := query.Get("scan_item.id"); projectID != "" && issue.ProjectID != projectID {
+				continue
+			}
+			ignored := issue.Ignored || len(org.ignores[issue.ProjectID][issue.Key]) > 0
+			resources = append(resources, issueResource(org.org.ID, issue, ignored))
+		}
+		writePage(w, r, resources)
+
+	default:
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s is not a Snyk API path", r.URL.Path))
+	}
+}
+
+// deleteGroupMembership removes the membership from the group. If `cascade` is set, the user is also removed from
+// every org in the group.
+func (s *Server) deleteGroupMembership(w http.ResponseWriter, groupID, membershipID string, cascade bool) {
+	members := s.groupMembers[groupID]
+	for i, m := range members {
+		if m.ID != membershipID {
+			continue
+		}
+
+		s.groupMembers[groupID] = append(members[:i], members[i+1:]...)
+		if cascade {
+			for _, org := range s.orgs {
+				if org.org.GroupID == groupID {
+					org.removeMember(m.UserID)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Membership %s was not found", membershipID))
+}
+
+// removeMember removes the user's membership of the org, if they have one
+func (o *orgState) removeMember(userID string) {
+	members := []Membership{}
+	for _, m := range o.members {
+		if m.UserID != userID {
+			members = append(members, m)
+		}
+	}
+	o.members = members
+}
+
+// updateProject applies a JSON:API patch of the project's attributes and owner relationship
+func updateProject(w http.ResponseWriter, r *http.Request, org *orgState, project *Project) {
+	body := struct {
+		Data struct {
+			Attributes struct {
+				BusinessCriticality *[]string   `json:"business_criticality"`
+				Environment         *[]string   `json:"environment"`
+				Lifecycle           *[]string   `json:"lifecycle"`
+				Tags                *[]snyk.Tag `json:"tags"`
+			} `json:"attributes"`
+			Relationships struct {
+				Owner *struct {
+					Data *struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"owner"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", "The request body is not valid JSON")
+		return
+	}
+
+	attributes := body.Data.Attributes
+	if attributes.BusinessCriticality != nil {
+		project.BusinessCriticality = *attributes.BusinessCriticality
+	}
+	if attributes.Environment != nil {
+		project.Environment = *attributes.Environment
+	}
+	if attributes.Lifecycle != nil {
+		project.Lifecycle = *attributes.Lifecycle
+	}
+	if attributes.Tags != nil {
+		project.Tags = *attributes.Tags
+	}
+	if owner := body.Data.Relationships.Owner; owner != nil {
+		project.OwnerID = ""
+		if owner.Data != nil {
+			project.OwnerID = owner.Data.ID
+		}
+	}
+
+	writeSingle(w, org.projectResource(project))
+}
+
+func isPath(segments []string, pattern ...string) bool {
+	_, ok := match(segments, pattern...)
+	return ok
+}
+
+func groupResource(g Group) resource {
+	return resource{
+		Type:       "group",
+		ID:         g.ID,
+		Attributes: map[string]any{"name": g.Name},
+	}
+}
+
+// orgMatches reports whether the org matches the `group_id`, `name` and `slug` filters of the query
+func orgMatches(o Org, query url.Values) bool {
+	if slug := query.Get("slug"); slug != "" && o.Slug != slug {
+		return false
+	}
+	if groupID := query.Get("group_id"); groupID != "" && o.GroupID != groupID {
+		return false
+	}
+	if name := query.Get("name"); name != "" && !strings.Contains(strings.ToLower(o.Name), strings.ToLower(name)) {
+		return false
+	}
+	return true
+}
+
+func orgResource(o Org) resource {
+	return resource{
+		Type: "org",
+		ID:   o.ID,
+		Attributes: map[string]any{
+			"name":        o.Name,
+			"slug":        o.Slug,
+			"group_id":    o.GroupID,
+			"is_personal": o.GroupID == "",
+		},
+	}
+}
+
+// membershipResource returns the membership as a JSON:API resource of `membershipType`, with its user and role
+// relationships
+func membershipResource(membershipType, roleType string, m Membership) resource {
+	user := newRelationship("user", m.UserID)
+	user.Data.Attributes = map[string]any{"name": m.Name, "username": m.Username, "email": m.Email}
+	role := newRelationship(roleType, m.RoleID)
+	role.Data.Attributes = map[string]any{"name": m.Role}
+
+	return resource{
+		Type:          membershipType,
+		ID:            m.ID,
+		Attributes:    map[string]any{},
+		Relationships: map[string]relationship{"user": user, "role": role},
+	}
+}
+
+func targetResource(t Target) resource {
+	return resource{
+		Type: "target",
+		ID:   t.ID,
+		Attributes: map[string]any{
+			"displayName": t.DisplayName,
+			"origin":      t.Origin,
+			"remoteUrl":   t.RemoteURL,
+			"isPrivate":   t.IsPrivate,
+		},
+	}
+}
+
+func (o *orgState) projectResource(p *Project) resource {
+	tags := []map[string]string{}
+	for _, t := range p.Tags {
+		tags = append(tags, map[string]string{"key": t.Key, "value": t.Value})
+	}
+
+	counts := map[string]any{"critical": 0, "high": 0, "medium": 0, "low": 0, "updated_at": time.Now().UTC()}
+	for _, issue := range o.v1Issues[p.ID] {
+		if n, ok := counts[issue.IssueData.Severity].(int); ok {
+			counts[issue.IssueData.Severity] = n + 1
+		}
+	}
+
+	res := resource{
+		Type: "project",
+		ID:   p.ID,
+		Attributes: map[string]any{
+			"name":                 p.Name,
+			"type":                 p.Type,
+			"target_file":          p.TargetFile,
+			"target_reference":     p.TargetReference,
+			"origin":               p.Origin,
+			"created":              p.Created,
+			"status":               p.Status,
+			"business_criticality": p.BusinessCriticality,
+			"environment":          p.Environment,
+			"lifecycle":            p.Lifecycle,
+			"tags":                 tags,
+			"read_only":            p.ReadOnly,
+		},
+		Relationships: map[string]relationship{
+			"organization": newRelationship("org", o.org.ID),
+		},
+		Meta: map[string]any{"latest_issue_counts": counts},
+	}
+	if p.TargetID != "" {
+		res.Relationships["target"] = newRelationship("target", p.TargetID)
+	}
+	if p.OwnerID != "" {
+		res.Relationships["owner"] = newRelationship("user", p.OwnerID)
+	}
+
+	return res
+}
+
+// projectMatches reports whether the project matches the filters of the list projects endpoint in `query`
+func projectMatches(p *Project, query url.Values) bool {
+	tags := []string{}
+	for _, t := range p.Tags {
+		tags = append(tags, t.Key+":"+t.Value)
+	}
+
+	filters := []struct {
+		param  string
+		values []string
+	}{
+		{"target_id", []string{p.TargetID}},
+		{"target_reference", []string{p.TargetReference}},
+		{"target_file", []string{p.TargetFile}},
+		{"ids", []string{p.ID}},
+		{"names", []string{p.Name}},
+		{"origins", []string{p.Origin}},
+		{"types", []string{p.Type}},
+		{"business_criticality", p.BusinessCriticality},
+		{"environment", p.Environment},
+		{"lifecycle", p.Lifecycle},
+	}
+	for _, f := range filters {
+		if query.Has(f.param) && !containsAny(f.values, strings.Split(query.Get(f.param), ",")) {
+			return false
+		}
+	}
+
+	if query.Has("names_start_with") {
+		prefixed := false
+		for _, prefix := range strings.Split(query.Get("names_start_with"), ",") {
+			prefixed = prefixed || strings.HasPrefix(p.Name, prefix)
+		}
+		if !prefixed {
+			return false
+		}
+	}
+
+	// Projects must have every tag in the filter
+	if query.Has("tags") {
+		for _, tag := range strings.Split(query.Get("tags"), ",") {
+			if !contains(tags, tag) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, w := range wanted {
+		if contains(values, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandTarget adds the attributes of the project's target to its target relationship
+func (o *orgState) expandTarget(res resource) {
+	rel, ok := res.Relationships["target"]
+	if !ok {
+		return
+	}
+	for _, t := range o.targets {
+		if t.ID == rel.Data.ID {
+			rel.Data.Attributes = map[string]any{"display_name": t.DisplayName, "url": t.RemoteURL}
+			res.Relationships["target"] = rel
+			return
+		}
+	}
+}
+
+func containerImageResource(image ContainerImage) resource {
+	return resource{
+		Type: "container_image",
+		ID:   image.ID,
+		Attributes: map[string]any{
+			"layers":   image.Layers,
+			"names":    image.Names,
+			"platform": image.Platform,
+		},
+	}
+}
+
+func issueResource(orgID string, issue *IssueV2, ignored bool) resource {
+	return resource{
+		Type: "issue",
+		ID:   issue.ID,
+		Attributes: map[string]any{
+			"key":                      issue.Key,
+			"title":                    issue.Title,
+			"type":                     issue.Type,
+			"status":                   issue.Status,
+			"effective_severity_level": issue.EffectiveSeverityLevel,
+			"ignored":                  ignored,
+			"created_at":               issue.CreatedAt,
+			"updated_at":               issue.UpdatedAt,
+		},
+		Relationships: map[string]relationship{
+			"organization": newRelationship("organization", orgID),
+			"scan_item":    newRelationship("project", issue.ProjectID),
+		},
+	}
+}
@@ -0,0 +1,218 @@
+// Package snyktest provides an in-memory fake of the Snyk API for testing code which uses the snyk package.
+//
+// The fake server stores groups, orgs, their memberships, targets, projects, container images and issues, and serves
+// them from the same REST and v1 endpoints that the snyk package calls, including JSON:API pagination. Mutations made
+// through the client, such as moving or deleting projects, ignoring issues and removing members, are applied to the
+// stored data so tests can assert on them.
+//
+//	server := snyktest.NewServer()
+//	defer server.Close()
+//
+//	org := server.AddOrg(snyktest.Org{Name: "org1"})
+//	server.AddProject(org.ID, snyktest.Project{Name: "project1"})
+//
+//	client := server.Client()
+//	snykOrg, err := client.Orgs.Get(org.ID)
+//	projects, err := snykOrg.Projects.GetAll()
+package snyktest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// Server is a fake Snyk API backed by an in-memory model
+type Server struct {
+	*httptest.Server
+	// Token is the API token that requests must be authorized with. If empty, any token is accepted.
+	Token string
+
+	mu     sync.Mutex
+	groups []Group
+	orgs   []*orgState
+	// Group settings and memberships by group ID
+	groupSettings map[string]snyk.GroupSettings
+	groupMembers  map[string][]Membership
+}
+
+// NewServer starts a fake Snyk API server. It must be closed with Close once the test is done.
+func NewServer() *Server {
+	s := &Server{groupSettings: map[string]snyk.GroupSettings{}, groupMembers: map[string][]Membership{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a snyk.Client which sends its requests to the fake server. Any additional options are applied after
+// the base URL is set.
+func (s *Server) Client(opts ...snyk.ClientOption) *snyk.Client {
+	token := s.Token
+	if token == "" {
+		token = "token snyktest"
+	}
+	opts = append([]snyk.ClientOption{snyk.WithBaseURL(s.URL)}, opts...)
+	return snyk.NewClient(token, opts...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if auth == "" || (s.Token != "" && auth != s.Token) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "The API token is missing or invalid")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch segments[0] {
+	case "rest":
+		if r.URL.Query().Get("version") == "" {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The version query parameter is required")
+			return
+		}
+		s.serveREST(w, r, segments[1:])
+	case "v1":
+		s.serveV1(w, r, segments[1:])
+	default:
+		writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s is not a Snyk API path", r.URL.Path))
+	}
+}
+
+// match reports whether the path segments match the pattern, where `*` matches any single segment. The matched
+// segments are returned in order.
+func match(segments []string, pattern ...string) ([]string, bool) {
+	if len(segments) != len(pattern) {
+		return nil, false
+	}
+
+	var params []string
+	for i, p := range pattern {
+		if p == "*" {
+			params = append(params, segments[i])
+		} else if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// resource is a JSON:API resource object
+type resource struct {
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id"`
+	Attributes    map[string]any          `json:"attributes"`
+	Relationships map[string]relationship `json:"relationships,omitempty"`
+	Meta          map[string]any          `json:"meta,omitempty"`
+}
+
+type relationship struct {
+	Data struct {
+		ID         string         `json:"id"`
+		Type       string         `json:"type"`
+		Attributes map[string]any `json:"attributes,omitempty"`
+	} `json:"data"`
+}
+
+func newRelationship(resourceType, id string) relationship {
+	rel := relationship{}
+	rel.Data.ID = id
+	rel.Data.Type = resourceType
+	return rel
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, title, detail string) {
+	writeJSON(w, status, map[string]any{
+		"jsonapi": map[string]string{"version": "1.0"},
+		"errors": []map[string]string{{
+			"status": strconv.Itoa(status),
+			"title":  title,
+			"detail": detail,
+		}},
+	})
+}
+
+func writeSingle(w http.ResponseWriter, res resource) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"jsonapi": map[string]string{"version": "1.0"},
+		"data":    res,
+	})
+}
+
+// writePage writes the page of `resources` selected by the `limit` and `starting_after` query parameters, along with
+// a "next" link if there are more resources after the page
+func writePage(w http.ResponseWriter, r *http.Request, resources []resource) {
+	query := r.URL.Query()
+
+	limit := defaultPageSize
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil {
+		limit = l
+	}
+	if limit < 1 || limit > maxPageSize {
+		writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("limit must be between 1 and %d", maxPageSize))
+		return
+	}
+
+	start := 0
+	if cursor := query.Get("starting_after"); cursor != "" {
+		id, err := decodeCursor(cursor)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request", "starting_after is not a valid cursor")
+			return
+		}
+		for i, res := range resources {
+			if res.ID == id {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(resources) {
+		end = len(resources)
+	}
+	page := append([]resource{}, resources[start:end]...)
+
+	links := map[string]string{"self": r.URL.RequestURI()}
+	if end < len(resources) {
+		query.Set("starting_after", encodeCursor(page[len(page)-1].ID))
+		links["next"] = (&url.URL{Path: r.URL.Path, RawQuery: query.Encode()}).String()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"jsonapi": map[string]string{"version": "1.0"},
+		"data":    page,
+		"links":   links,
+	})
+}
+
+// Cursors are opaque to clients, so they are encoded to stop tests from depending on them being IDs
+func encodeCursor(id string) string {
+	return "v1." + base64.URLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	id, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(cursor, "v1."))
+	return string(id), err
+}
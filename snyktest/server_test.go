@@ -0,0 +1,417 @@
+package snyktest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+)
+
+func TestServerPaginatesProjects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org := server.AddOrg(Org{Name: "org1", Slug: "org1-abc"})
+	for i := 0; i < 25; i++ {
+		server.AddProject(org.ID, Project{Name: "project", Type: "npm"})
+	}
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get("org1-abc")
+	assert.NoError(t, err)
+	assert.Equal(t, org.ID, snykOrg.ID)
+
+	pager := snykOrg.Projects.Pager(snyk.PageOptions{Limit: 10})
+	pages := 0
+	total := 0
+	for pager.More() {
+		projects, err := pager.Next(context.Background())
+		assert.NoError(t, err)
+		pages++
+		total += len(projects)
+	}
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, 25, total)
+}
+
+func TestServerFiltersProjects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org := server.AddOrg(Org{Name: "org1"})
+	target := server.AddTarget(org.ID, Target{DisplayName: "snyk/goof", RemoteURL: "https://github.com/snyk/goof"})
+	production := server.AddProject(org.ID, Project{
+		Name:      "snyk/goof:package.json",
+		Origin:    "github",
+		TargetID:  target.ID,
+		Lifecycle: []string{"production"},
+		Tags:      []snyk.Tag{{Key: "team", Value: "appsec"}},
+	})
+	server.AddProject(org.ID, Project{Name: "snyk/goof:Dockerfile", Origin: "github", Lifecycle: []string{"development"}})
+	server.AddProject(org.ID, Project{Name: "cli-project", Origin: "cli", Lifecycle: []string{"production"}})
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+
+	projects, err := snykOrg.Projects.GetAll(snyk.ListProjectsOptions{
+		Origins:        []string{"github"},
+		Lifecycle:      []string{"production"},
+		NamesStartWith: []string{"snyk/"},
+		Tags:           []snyk.Tag{{Key: "team", Value: "appsec"}},
+		ExpandTarget:   true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(projects))
+	assert.Equal(t, production.ID, projects[0].ID)
+	assert.Equal(t, &snyk.ProjectTarget{ID: target.ID, DisplayName: "snyk/goof", URL: target.RemoteURL}, projects[0].Target)
+
+	projects, err = snykOrg.Projects.GetAll(snyk.ListProjectsOptions{Origins: []string{"github", "cli"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(projects))
+}
+
+func TestServerUpdatesProjects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org := server.AddOrg(Org{Name: "org1"})
+	project := server.AddProject(org.ID, Project{Name: "project1", Lifecycle: []string{"development"}})
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	snykProject, err := snykOrg.Projects.Get(project.ID)
+	assert.NoError(t, err)
+
+	ownerID := "e661d4ef-5ad5-4cef-ad16-5157cefa83f5"
+	err = snykProject.Update(snyk.ProjectUpdate{Environment: []string{"external"}, OwnerID: &ownerID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"external"}, snykProject.Environment)
+	assert.Equal(t, []string{"development"}, snykProject.Lifecycle)
+	assert.Equal(t, ownerID, snykProject.Relationships["owner"].Data.ID)
+
+	assert.NoError(t, snykProject.AddTag(snyk.Tag{Key: "team", Value: "appsec"}))
+	assert.NoError(t, snykProject.AddTag(snyk.Tag{Key: "env", Value: "prod"}))
+	assert.NoError(t, snykProject.RemoveTag(snyk.Tag{Key: "team", Value: "appsec"}))
+	assert.Equal(t, []snyk.Tag{{Key: "env", Value: "prod"}}, snykProject.Tags)
+
+	stored, _, _ := server.Project(project.ID)
+	assert.Equal(t, []string{"external"}, stored.Environment)
+	assert.Equal(t, ownerID, stored.OwnerID)
+	assert.Equal(t, []snyk.Tag{{Key: "env", Value: "prod"}}, stored.Tags)
+
+	assert.NoError(t, snykProject.Deactivate())
+	stored, _, _ = server.Project(project.ID)
+	assert.Equal(t, "inactive", stored.Status)
+	assert.NoError(t, snykProject.Activate())
+	stored, _, _ = server.Project(project.ID)
+	assert.Equal(t, "active", stored.Status)
+
+	enabled := true
+	assert.NoError(t, snykProject.UpdateSettings(snyk.ProjectSettings{PullRequestTestEnabled: &enabled}))
+	assert.NoError(t, snykProject.UpdateSettings(snyk.ProjectSettings{RecurringTests: &snyk.RecurringTests{Frequency: "weekly"}}))
+	settings, err := snykProject.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, true, *settings.PullRequestTestEnabled)
+	assert.Equal(t, "weekly", settings.RecurringTests.Frequency)
+
+	assert.NoError(t, snykProject.DeleteSettings())
+	settings, err = snykProject.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, snyk.ProjectSettings{}, settings)
+}
+
+func TestServerPaginatesProjectHistory(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org := server.AddOrg(Org{Name: "org1"})
+	project := server.AddProject(org.ID, Project{Name: "project1"})
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 15; i++ {
+		snapshot := snyk.ProjectSnapshot{Created: start.Add(time.Duration(i) * time.Hour)}
+		snapshot.IssueCounts.Vuln.High = 15 - i
+		server.AddSnapshot(org.ID, project.ID, snapshot)
+	}
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	snykProject, err := snykOrg.Projects.Get(project.ID)
+	assert.NoError(t, err)
+
+	snapshots, err := snykProject.History(snyk.ProjectHistoryOptions{PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 15, len(snapshots))
+	assert.Equal(t, 1, snapshots[0].IssueCounts.Vuln.High)
+
+	series := snyk.NewIssueCountSeries(snapshots)
+	assert.Equal(t, start, series[0].Time)
+	assert.Equal(t, -14, series.Trend().High)
+
+	snapshots, err = snykProject.History(snyk.ProjectHistoryOptions{PerPage: 10, Page: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(snapshots))
+}
+
+func TestServerExportsSBOMs(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org := server.AddOrg(Org{Name: "org1"})
+	npm := server.AddProject(org.ID, Project{Name: "npm", Type: "npm"})
+	code := server.AddProject(org.ID, Project{Name: "code", Type: "sast"})
+	server.SetSBOM(org.ID, npm.ID, snyk.SBOMFormatSPDX23JSON, `{"packages":[{"name":"express","versionInfo":"4.12.4"}]}`)
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+
+	exported := map[string]*bytes.Buffer{}
+	err = snykOrg.Projects.ExportSBOMs(context.Background(), snyk.SBOMFormatSPDX23JSON, snyk.ListProjectsOptions{},
+		func(project snyk.Project) (io.WriteCloser, error) {
+			exported[project.ID] = &bytes.Buffer{}
+			return nopWriteCloser{exported[project.ID]}, nil
+		})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), code.ID)
+
+	components, err := snyk.ParseSBOMComponents(exported[npm.ID], snyk.SBOMFormatSPDX23JSON)
+	assert.NoError(t, err)
+	assert.Equal(t, []snyk.SBOMComponent{{Name: "express", Version: "4.12.4"}}, components)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestServerMovesAndDeletesProjects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org1 := server.AddOrg(Org{Name: "org1"})
+	org2 := server.AddOrg(Org{Name: "org2"})
+	moved := server.AddProject(org1.ID, Project{Name: "moved"})
+	deleted := server.AddProject(org1.ID, Project{Name: "deleted"})
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get(org1.ID)
+	assert.NoError(t, err)
+
+	project, err := snykOrg.Projects.Get(moved.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, project.Move(org2.ID))
+
+	project, err = snykOrg.Projects.Get(deleted.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, project.Delete())
+
+	assert.Equal(t, 0, len(server.Projects(org1.ID)))
+	assert.Equal(t, []Project{moved}, server.Projects(org2.ID))
+
+	graph := snyk.DepGraph{Pkgs: []snyk.DepGraphPkg{{ID: "app@1.0.0", Info: snyk.PkgInfo{Name: "app", Version: "1.0.0"}}}}
+	graph.Graph.RootNodeID = "root-node"
+	graph.Graph.Nodes = []snyk.DepGraphNode{{NodeID: "root-node", PkgID: "app@1.0.0"}}
+	server.SetDepGraph(org2.ID, moved.ID, graph)
+
+	snykOrg2, err := client.Orgs.Get(org2.ID)
+	assert.NoError(t, err)
+	project, err = snykOrg2.Projects.Get(moved.ID)
+	assert.NoError(t, err)
+	movedGraph, err := project.GetDepGraph()
+	assert.NoError(t, err)
+	assert.Equal(t, snyk.PkgInfo{Name: "app", Version: "1.0.0"}, movedGraph.Root())
+
+	_, err = snykOrg.Projects.Get(moved.ID)
+	assert.True(t, errors.Is(err, snyk.ErrNotFound))
+}
+
+func TestServerManagesOrgs(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	group := server.AddGroup(Group{Name: "group1"})
+	server.AddOrg(Org{Name: "Platform Team", GroupID: group.ID})
+	server.AddOrg(Org{Name: "Other Team"})
+	nonEmpty := server.AddOrg(Org{Name: "Platform Apps", GroupID: group.ID})
+	server.AddProject(nonEmpty.ID, Project{Name: "project1"})
+
+	client := server.Client()
+	orgs, err := client.Orgs.GetAll(snyk.ListOrgsOptions{GroupID: group.ID, Name: "platform"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(orgs))
+
+	created, err := client.Orgs.Create(group.ID, "new-org", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, group.ID, created.GroupID)
+	assert.NoError(t, created.Update("renamed-org"))
+	assert.Equal(t, "renamed-org", created.Name)
+
+	projects, err := created.Projects.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(projects))
+
+	snykOrg, err := client.Orgs.Get(nonEmpty.ID)
+	assert.NoError(t, err)
+	err = snykOrg.Delete(context.Background())
+	assert.True(t, errors.Is(err, snyk.ErrOrgNotEmpty))
+	assert.NoError(t, snykOrg.Delete(context.Background(), snyk.DeleteOrgOptions{Force: true}))
+	assert.NoError(t, created.Delete(context.Background()))
+
+	assert.Equal(t, 2, len(server.Orgs()))
+	_, err = client.Orgs.Get(created.ID)
+	assert.True(t, errors.Is(err, snyk.ErrNotFound))
+}
+
+func TestServerManagesGroups(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	group := server.AddGroup(Group{Name: "group1"})
+	org1 := server.AddOrg(Org{Name: "org1", GroupID: group.ID})
+	org2 := server.AddOrg(Org{Name: "org2", GroupID: group.ID})
+	server.AddOrg(Org{Name: "other"})
+	server.AddProject(org1.ID, Project{Name: "project1", Tags: []snyk.Tag{{Key: "team", Value: "a"}, {Key: "env", Value: "prod"}}})
+	server.AddProject(org2.ID, Project{Name: "project2", Tags: []snyk.Tag{{Key: "team", Value: "a"}}})
+
+	client := server.Client()
+	snykGroup, err := client.Groups.Get(group.ID)
+	assert.NoError(t, err)
+
+	orgs, err := snykGroup.Orgs()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(orgs))
+
+	enabled := true
+	assert.NoError(t, snykGroup.UpdateSettings(snyk.GroupSettings{RequestAccess: &snyk.GroupRequestAccess{Enabled: &enabled}}))
+	assert.NoError(t, snykGroup.UpdateSettings(snyk.GroupSettings{SessionLength: 90}))
+	settings, err := snykGroup.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, 90, settings.SessionLength)
+	assert.True(t, *settings.RequestAccess.Enabled)
+
+	tags, err := snykGroup.Tags()
+	assert.NoError(t, err)
+	assert.Equal(t, []snyk.Tag{{Key: "team", Value: "a"}, {Key: "env", Value: "prod"}}, tags)
+
+	err = snykGroup.DeleteTag(snyk.Tag{Key: "team", Value: "a"})
+	var apiErr *snyk.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 422, apiErr.StatusCode)
+	assert.NoError(t, snykGroup.DeleteTag(snyk.Tag{Key: "team", Value: "a"}, snyk.DeleteTagOptions{Force: true}))
+
+	tags, err = snykGroup.Tags()
+	assert.NoError(t, err)
+	assert.Equal(t, []snyk.Tag{{Key: "env", Value: "prod"}}, tags)
+	assert.Equal(t, 0, len(server.Projects(org2.ID)[0].Tags))
+}
+
+func TestServerManagesMemberships(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	group := server.AddGroup(Group{Name: "group1"})
+	org1 := server.AddOrg(Org{Name: "org1", GroupID: group.ID})
+	org2 := server.AddOrg(Org{Name: "org2", GroupID: group.ID})
+	alice := server.AddGroupMember(group.ID, Membership{Name: "Alice", Email: "alice@example.com", Role: "Group Member"})
+	bob := server.AddGroupMember(group.ID, Membership{Name: "Bob", Email: "bob@example.com", Role: "Group Admin"})
+	server.AddOrgMember(org1.ID, Membership{UserID: alice.UserID, Email: alice.Email, Role: "Org Admin"})
+	server.AddOrgMember(org2.ID, Membership{UserID: alice.UserID, Email: alice.Email, Role: "Org Collaborator"})
+	server.AddOrgMember(org1.ID, Membership{UserID: bob.UserID, Email: bob.Email, Role: "Org Collaborator"})
+
+	client := server.Client()
+	snykGroup, err := client.Groups.Get(group.ID)
+	assert.NoError(t, err)
+
+	members, err := snykGroup.Memberships(snyk.GroupMembershipsOptions{IncludeOrgRoles: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(members))
+	assert.Equal(t, "Group Member", members[0].GroupRole)
+	assert.Equal(t, []snyk.GroupMemberOrg{
+		{OrgID: org1.ID, OrgName: "org1", Role: "Org Admin"},
+		{OrgID: org2.ID, OrgName: "org2", Role: "Org Collaborator"},
+	}, members[0].Orgs)
+
+	// Removing a member through the cache removes them from the group's orgs too
+	ctx := context.Background()
+	cache := snyk.NewMembershipCache(snykGroup, time.Minute)
+	member, err := cache.MemberByEmail(ctx, "ALICE@example.com")
+	assert.NoError(t, err)
+	assert.NoError(t, cache.RemoveMember(ctx, member))
+	assert.Equal(t, []Membership{bob}, server.GroupMembers(group.ID))
+	assert.Equal(t, 1, len(server.OrgMembers(org1.ID)))
+	assert.Equal(t, 0, len(server.OrgMembers(org2.ID)))
+	_, err = cache.Member(ctx, alice.UserID)
+	assert.True(t, errors.Is(err, snyk.ErrNotFound))
+
+	snykOrg, err := client.Orgs.Get(org1.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, snykOrg.RemoveMember(bob.UserID))
+	assert.Equal(t, 0, len(server.OrgMembers(org1.ID)))
+	err = snykOrg.RemoveMember(bob.UserID)
+	assert.True(t, errors.Is(err, snyk.ErrNotFound))
+}
+
+func TestServerIgnoresIssues(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	org := server.AddOrg(Org{Name: "org1"})
+	project := server.AddProject(org.ID, Project{Name: "project1"})
+	issue := snyk.Issue{ID: "SNYK-JS-LODASH-567746", IssueType: "vuln", PkgName: "lodash"}
+	issue.IssueData.Severity = "high"
+	server.AddIssue(org.ID, project.ID, issue)
+	server.AddIssueV2(org.ID, IssueV2{Key: issue.ID, Type: "package_vulnerability", ProjectID: project.ID})
+
+	client := server.Client()
+	snykOrg, err := client.Orgs.Get(org.ID)
+	assert.NoError(t, err)
+	snykProject, err := snykOrg.Projects.Get(project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, snykProject.Meta.LatestIssueCounts.High)
+
+	issues, err := snykProject.Issues.GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(issues))
+	assert.False(t, issues[0].IsIgnored)
+
+	err = issues[0].AddIgnore(snyk.IgnoreOptions{Reason: "not used", ReasonType: "not-vulnerable"})
+	assert.NoError(t, err)
+
+	ignored, err := snykProject.Issues.GetIgnored()
+	assert.NoError(t, err)
+	assert.Equal(t, "not used", ignored[issue.ID][0].Reason)
+	assert.Equal(t, "*", ignored[issue.ID][0].Path[0].Module)
+	assert.Equal(t, 1, len(server.Ignores(org.ID, project.ID)))
+
+	issuesV2, err := snykOrg.Issues.GetAllV2()
+	assert.NoError(t, err)
+	assert.True(t, issuesV2[0].Ignored)
+
+	assert.NoError(t, issuesV2[0].DeleteIgnore())
+	assert.Equal(t, 0, len(server.Ignores(org.ID, project.ID)))
+}
+
+func TestServerRequiresAuthorization(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Token = "token expected"
+
+	client := snyk.NewClient("token wrong", snyk.WithBaseURL(server.URL))
+	_, err := client.Orgs.GetAll()
+	assert.True(t, errors.Is(err, snyk.ErrUnauthorized))
+
+	_, err = server.Client().Orgs.GetAll()
+	assert.NoError(t, err)
+}
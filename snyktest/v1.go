@@ -0,0 +1,494 @@
+package snyktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"snyk/Application-Security/snyk-sdk/snyk"
+)
+
+// serveV1 handles requests to the v1 API. `segments` is the request path without the leading `v1`.
+func (s *Server) serveV1(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) >= 2 && segments[0] == "group" {
+		s.serveV1Group(w, r, segments[1], segments[2:])
+		return
+	}
+
+	if isPath(segments, "org") && r.Method == http.MethodPost {
+		s.createOrg(w, r)
+		return
+	}
+
+	if params, ok := match(segments, "org", "*"); ok && r.Method == http.MethodDelete {
+		for i, org := range s.orgs {
+			if org.org.ID == params[0] {
+				s.orgs = append(s.orgs[:i], s.orgs[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Org %s was not found", params[0]))
+		return
+	}
+
+	if len(segments) < 4 || segments[0] != "org" || segments[2] != "project" {
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("%s is not a Snyk API path", r.URL.Path))
+		return
+	}
+
+	org := s.org(segments[1])
+	if org == nil {
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Org %s was not found", segments[1]))
+		return
+	}
+
+	project, index := org.project(segments[3])
+	if project == nil {
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Project %s was not found", segments[3]))
+		return
+	}
+	segments = segments[4:]
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodDelete:
+		org.projects = append(org.projects[:index], org.projects[index+1:]...)
+		delete(org.v1Issues, project.ID)
+		delete(org.ignores, project.ID)
+		delete(org.settings, project.ID)
+		delete(org.depGraphs, project.ID)
+		delete(org.snapshots, project.ID)
+		delete(org.sboms, project.ID)
+		w.WriteHeader(http.StatusOK)
+
+	case isPath(segments, "deactivate") && r.Method == http.MethodPost:
+		project.Status = "inactive"
+		w.WriteHeader(http.StatusOK)
+
+	case isPath(segments, "activate") && r.Method == http.MethodPost:
+		project.Status = "active"
+		w.WriteHeader(http.StatusOK)
+
+	case isPath(segments, "settings"):
+		serveProjectSettings(w, r, org, project)
+
+	case isPath(segments, "dep-graph") && r.Method == http.MethodGet:
+		graph, ok := org.depGraphs[project.ID]
+		if !ok {
+			writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Project %s has no dep graph", project.ID))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"depGraph": graph})
+
+	case isPath(segments, "history") && r.Method == http.MethodPost:
+		writeHistoryPage(w, r, org.snapshots[project.ID])
+
+	case isPath(segments, "move") && r.Method == http.MethodPut:
+		s.moveProject(w, r, org, project, index)
+
+	case isPath(segments, "tags") && r.Method == http.MethodPost:
+		s.updateTags(w, r, project, true)
+
+	case isPath(segments, "tags", "remove") && r.Method == http.MethodPost:
+		s.updateTags(w, r, project, false)
+
+	case isPath(segments, "aggregated-issues") && r.Method == http.MethodPost:
+		issues := []snyk.Issue{}
+		for _, issue := range org.v1Issues[project.ID] {
+			issue.IsIgnored = issue.IsIgnored || len(org.ignores[project.ID][issue.ID]) > 0
+			issues = append(issues, issue)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"issues": issues})
+
+	case isPath(segments, "ignores") && r.Method == http.MethodGet:
+		// Ignores are keyed by issue ID, then listed by the path they apply to
+		body := map[string][]map[string]snyk.Ignore{}
+		for issueID, ignores := range org.ignores[project.ID] {
+			for _, ignore := range ignores {
+				path := "*"
+				if len(ignore.Path) > 0 {
+					path = ignore.Path[0].Module
+				}
+				ignore.Path = nil
+				body[issueID] = append(body[issueID], map[string]snyk.Ignore{path: ignore})
+			}
+		}
+		writeJSON(w, http.StatusOK, body)
+
+	case isPath(segments, "ignore", "*"):
+		s.serveIgnore(w, r, org, project, segments[1])
+
+	default:
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("%s is not a Snyk API path", r.URL.Path))
+	}
+}
+
+// serveV1Group handles requests to the v1 group endpoints. `segments` is the request path after the group ID.
+func (s *Server) serveV1Group(w http.ResponseWriter, r *http.Request, groupID string, segments []string) {
+	if !s.hasGroup(groupID) {
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Group %s was not found", groupID))
+		return
+	}
+
+	switch {
+	case isPath(segments, "settings") && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.groupSettings[groupID])
+
+	case isPath(segments, "settings") && r.Method == http.MethodPut:
+		settings := s.groupSettings[groupID]
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			writeV1Error(w, http.StatusBadRequest, "The request body is not valid JSON")
+			return
+		}
+		s.groupSettings[groupID] = settings
+		writeJSON(w, http.StatusOK, settings)
+
+	case isPath(segments, "tags") && r.Method == http.MethodGet:
+		writeGroupTagsPage(w, r, s.groupTags(groupID))
+
+	case isPath(segments, "tags", "delete") && r.Method == http.MethodPost:
+		s.deleteGroupTag(w, r, groupID)
+
+	default:
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("%s is not a Snyk API path", r.URL.Path))
+	}
+}
+
+func (s *Server) hasGroup(groupID string) bool {
+	for _, g := range s.groups {
+		if g.ID == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+// groupTags returns the distinct tags of the projects in the group's orgs, in the order they are first used
+func (s *Server) groupTags(groupID string) []snyk.Tag {
+	tags := []snyk.Tag{}
+	for _, org := range s.orgs {
+		if org.org.GroupID != groupID {
+			continue
+		}
+		for _, project := range org.projects {
+			for _, tag := range project.Tags {
+				if !containsTag(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	return tags
+}
+
+func writeGroupTagsPage(w http.ResponseWriter, r *http.Request, tags []snyk.Tag) {
+	page, perPage := 1, 1000
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		page = p
+	}
+	if p, err := strconv.Atoi(r.URL.Query().Get("perPage")); err == nil {
+		perPage = p
+	}
+	if page < 1 || perPage < 1 || perPage > 1000 {
+		writeV1Error(w, http.StatusBadRequest, "page must be positive and perPage between 1 and 1000")
+		return
+	}
+
+	start := (page - 1) * perPage
+	if start > len(tags) {
+		start = len(tags)
+	}
+	end := start + perPage
+	if end > len(tags) {
+		end = len(tags)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"tags": tags[start:end]})
+}
+
+func (s *Server) deleteGroupTag(w http.ResponseWriter, r *http.Request, groupID string) {
+	body := struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Force bool   `json:"force"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Key == "" || body.Value == "" {
+		writeV1Error(w, http.StatusBadRequest, "A tag key and value are required")
+		return
+	}
+	tag := snyk.Tag{Key: body.Key, Value: body.Value}
+
+	var tagged []*Project
+	for _, org := range s.orgs {
+		if org.org.GroupID != groupID {
+			continue
+		}
+		for _, project := range org.projects {
+			if containsTag(project.Tags, tag) {
+				tagged = append(tagged, project)
+			}
+		}
+	}
+
+	if len(tagged) > 0 && !body.Force {
+		writeV1Error(w, http.StatusUnprocessableEntity,
+			fmt.Sprintf("Tag %s:%s is used on %d projects. Use force to delete it from them.", tag.Key, tag.Value, len(tagged)))
+		return
+	}
+
+	for _, project := range tagged {
+		tags := []snyk.Tag{}
+		for _, t := range project.Tags {
+			if t != tag {
+				tags = append(tags, t)
+			}
+		}
+		project.Tags = tags
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func containsTag(tags []snyk.Tag, tag snyk.Tag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createOrg(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		Name    string `json:"name"`
+		GroupID string `json:"groupId"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeV1Error(w, http.StatusBadRequest, "The request body is not valid JSON")
+		return
+	}
+	if body.Name == "" {
+		writeV1Error(w, http.StatusBadRequest, "The org name is required")
+		return
+	}
+
+	id := newID()
+	org := Org{ID: id, Name: body.Name, Slug: id, GroupID: body.GroupID}
+	s.orgs = append(s.orgs, newOrgState(org))
+
+	resp := map[string]any{"id": org.ID, "name": org.Name, "slug": org.Slug}
+	if org.GroupID != "" {
+		resp["group"] = map[string]string{"id": org.GroupID}
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) moveProject(w http.ResponseWriter, r *http.Request, org *orgState, project *Project, index int) {
+	body := struct {
+		TargetOrgID string `json:"targetOrgId"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeV1Error(w, http.StatusBadRequest, "The request body is not valid JSON")
+		return
+	}
+
+	target := s.org(body.TargetOrgID)
+	if target == nil {
+		writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Org %s was not found", body.TargetOrgID))
+		return
+	}
+
+	org.projects = append(org.projects[:index], org.projects[index+1:]...)
+	target.projects = append(target.projects, project)
+
+	target.v1Issues[project.ID] = org.v1Issues[project.ID]
+	delete(org.v1Issues, project.ID)
+	target.ignores[project.ID] = org.ignores[project.ID]
+	delete(org.ignores, project.ID)
+	target.settings[project.ID] = org.settings[project.ID]
+	delete(org.settings, project.ID)
+	if graph, ok := org.depGraphs[project.ID]; ok {
+		target.depGraphs[project.ID] = graph
+		delete(org.depGraphs, project.ID)
+	}
+	target.snapshots[project.ID] = org.snapshots[project.ID]
+	delete(org.snapshots, project.ID)
+	target.sboms[project.ID] = org.sboms[project.ID]
+	delete(org.sboms, project.ID)
+
+	var remaining []*IssueV2
+	for _, issue := range org.issues {
+		if issue.ProjectID == project.ID {
+			target.issues = append(target.issues, issue)
+		} else {
+			remaining = append(remaining, issue)
+		}
+	}
+	org.issues = remaining
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"originOrg":      org.org.ID,
+		"destinationOrg": target.org.ID,
+		"movedProject":   project.ID,
+	})
+}
+
+// serveProjectSettings handles the project settings endpoint. Updates are merged into the stored settings, so
+// settings which are omitted from the request are left unchanged.
+func serveProjectSettings(w http.ResponseWriter, r *http.Request, org *orgState, project *Project) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, org.settings[project.ID])
+
+	case http.MethodPut:
+		settings := org.settings[project.ID]
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			writeV1Error(w, http.StatusBadRequest, "The request body is not valid JSON")
+			return
+		}
+		org.settings[project.ID] = settings
+		writeJSON(w, http.StatusOK, settings)
+
+	case http.MethodDelete:
+		delete(org.settings, project.ID)
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeV1Error(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+// updateTags adds or removes a tag, responding with the project's resulting tags
+func (s *Server) updateTags(w http.ResponseWriter, r *http.Request, project *Project, add bool) {
+	tag := snyk.Tag{}
+	if err := json.NewDecoder(r.Body).Decode(&tag); err != nil || tag.Key == "" || tag.Value == "" {
+		writeV1Error(w, http.StatusBadRequest, "A tag key and value are required")
+		return
+	}
+
+	tags := []snyk.Tag{}
+	exists := false
+	for _, t := range project.Tags {
+		if t == tag {
+			exists = true
+			if !add {
+				continue
+			}
+		}
+		tags = append(tags, t)
+	}
+	if add && !exists {
+		tags = append(tags, tag)
+	}
+	project.Tags = tags
+
+	writeJSON(w, http.StatusOK, map[string]any{"tags": tags})
+}
+
+func (s *Server) serveIgnore(w http.ResponseWriter, r *http.Request, org *orgState, project *Project, issueID string) {
+	switch r.Method {
+	case http.MethodGet:
+		ignores := org.ignores[project.ID][issueID]
+		if len(ignores) == 0 {
+			writeV1Error(w, http.StatusNotFound, fmt.Sprintf("Issue %s is not ignored", issueID))
+			return
+		}
+		writeJSON(w, http.StatusOK, ignores[0])
+
+	case http.MethodPost, http.MethodPut:
+		opts := snyk.IgnoreOptions{}
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			writeV1Error(w, http.StatusBadRequest, "The request body is not valid JSON")
+			return
+		}
+
+		ignore, err := newIgnore(opts)
+		if err != nil {
+			writeV1Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if org.ignores[project.ID] == nil {
+			org.ignores[project.ID] = map[string][]snyk.Ignore{}
+		}
+		if r.Method == http.MethodPost {
+			org.ignores[project.ID][issueID] = append(org.ignores[project.ID][issueID], ignore)
+		} else {
+			org.ignores[project.ID][issueID] = []snyk.Ignore{ignore}
+		}
+		writeJSON(w, http.StatusOK, ignore)
+
+	case http.MethodDelete:
+		delete(org.ignores[project.ID], issueID)
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeV1Error(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+func newIgnore(opts snyk.IgnoreOptions) (snyk.Ignore, error) {
+	ignore := snyk.Ignore{
+		Reason:             opts.Reason,
+		Created:            time.Now().UTC().Truncate(time.Second),
+		ReasonType:         opts.ReasonType,
+		DisregardIfFixable: opts.DisregardIfFixable,
+	}
+	ignore.IgnoredBy.Name = "snyktest"
+
+	if opts.Expires != "" {
+		expires, err := time.Parse(time.RFC3339, opts.Expires)
+		if err != nil {
+			return snyk.Ignore{}, fmt.Errorf("expires must be an RFC 3339 timestamp")
+		}
+		ignore.Expires = &expires
+	}
+
+	path := opts.IgnorePath
+	if path == "" {
+		path = "*"
+	}
+	ignore.Path = append(ignore.Path, struct {
+		Module string `json:"module"`
+	}{Module: path})
+
+	return ignore, nil
+}
+
+// writeHistoryPage writes the page of snapshots selected by the `page` and `perPage` query parameters, newest first
+func writeHistoryPage(w http.ResponseWriter, r *http.Request, snapshots []snyk.ProjectSnapshot) {
+	page, perPage := 1, defaultPageSize
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		page = p
+	}
+	if p, err := strconv.Atoi(r.URL.Query().Get("perPage")); err == nil {
+		perPage = p
+	}
+	if page < 1 || perPage < 1 || perPage > maxPageSize {
+		writeV1Error(w, http.StatusBadRequest, fmt.Sprintf("page must be positive and perPage between 1 and %d", maxPageSize))
+		return
+	}
+
+	sorted := append([]snyk.ProjectSnapshot(nil), snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Created.After(sorted[j].Created)
+	})
+
+	start := (page - 1) * perPage
+	if start > len(sorted) {
+		start = len(sorted)
+	}
+	end := start + perPage
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"snapshots": sorted[start:end], "total": len(sorted)})
+}
+
+func writeV1Error(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"code": status, "message": message})
+}